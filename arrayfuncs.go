@@ -0,0 +1,369 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import (
+	"math"
+)
+
+// The slice functions below dispatch to the kernel for the element type:
+// the functions with suffix 64 operate on float64 slices and the ones with
+// suffix 32 on float32 slices. On AMD64 the kernels are implemented in
+// assembly, on other platforms they call the generic Go fallbacks that are
+// defined at the end of this file. Complex slices use the float kernels
+// when the operation is the same on the real and imaginary parts, and the
+// Go fallbacks otherwise. Integer slices always use the Go fallbacks.
+
+// maxValue returns the largest finite value of type T.
+func maxValue[T Float]() T {
+	if kindOf[T]().is64() {
+		v := math.MaxFloat64
+		return T(v)
+	}
+	v := float32(math.MaxFloat32)
+	return T(v)
+}
+
+// divSlice divides two slices
+// Assumptions the assembly can make:
+// out != nil, a != nil, b != nil
+// len(out)  == len(a) == len(b)
+func divSlice[T Elem](out, a, b []T) {
+	switch kindOf[T]() {
+	case float64Kind:
+		divSlice64(f64(out), f64(a), f64(b))
+	case float32Kind:
+		divSlice32(f32(out), f32(a), f32(b))
+	default:
+		divSliceGo(out, a, b)
+	}
+}
+
+// addSlice adds two slices
+// Assumptions the assembly can make:
+// out != nil, a != nil, b != nil
+// len(out)  == len(a) == len(b)
+func addSlice[T Elem](out, a, b []T) {
+	switch kindOf[T]() {
+	case float64Kind, complex128Kind:
+		addSlice64(f64(out), f64(a), f64(b))
+	case float32Kind, complex64Kind:
+		addSlice32(f32(out), f32(a), f32(b))
+	default:
+		addSliceGo(out, a, b)
+	}
+}
+
+// subSlice subtracts two slices
+// Assumptions the assembly can make:
+// out != nil, a != nil, b != nil
+// len(out)  == len(a) == len(b)
+func subSlice[T Elem](out, a, b []T) {
+	switch kindOf[T]() {
+	case float64Kind, complex128Kind:
+		subSlice64(f64(out), f64(a), f64(b))
+	case float32Kind, complex64Kind:
+		subSlice32(f32(out), f32(a), f32(b))
+	default:
+		subSliceGo(out, a, b)
+	}
+}
+
+// mulSlice multiply two slices
+// Assumptions the assembly can make:
+// out != nil, a != nil, b != nil
+// len(out)  == len(a) == len(b)
+func mulSlice[T Elem](out, a, b []T) {
+	switch kindOf[T]() {
+	case float64Kind:
+		mulSlice64(f64(out), f64(a), f64(b))
+	case float32Kind:
+		mulSlice32(f32(out), f32(a), f32(b))
+	default:
+		mulSliceGo(out, a, b)
+	}
+}
+
+// minSlice returns lowest valus of two slices
+// Assumptions the assembly can make:
+// out != nil, a != nil, b != nil
+// len(out)  == len(a) == len(b)
+func minSlice[T Real](out, a, b []T) {
+	switch kindOf[T]() {
+	case float64Kind:
+		minSlice64(f64(out), f64(a), f64(b))
+	case float32Kind:
+		minSlice32(f32(out), f32(a), f32(b))
+	default:
+		minSliceGo(out, a, b)
+	}
+}
+
+// maxSlice return maximum of two slices
+// Assumptions the assembly can make:
+// out != nil, a != nil, b != nil
+// len(out)  == len(a) == len(b)
+func maxSlice[T Real](out, a, b []T) {
+	switch kindOf[T]() {
+	case float64Kind:
+		maxSlice64(f64(out), f64(a), f64(b))
+	case float32Kind:
+		maxSlice32(f32(out), f32(a), f32(b))
+	default:
+		maxSliceGo(out, a, b)
+	}
+}
+
+// csignSlice returns a value with the magnitude of a and the sign of b
+// for each element in the slice.
+// Assumptions the assembly can make:
+// out != nil, a != nil, b != nil
+// len(out)  == len(a) == len(b)
+func csignSlice[T Float](out, a, b []T) {
+	if kindOf[T]().is64() {
+		csignSlice64(f64(out), f64(a), f64(b))
+		return
+	}
+	csignSlice32(f32(out), f32(a), f32(b))
+}
+
+// cdivSlice will return c / values of the array
+// Assumptions the assembly can make:
+// out != nil, a != nil
+// len(out)  == len(a)
+func cdivSlice[T Elem](out, a []T, c T) {
+	switch kindOf[T]() {
+	case float64Kind:
+		cdivSlice64(f64(out), f64(a), toFloat[float64](c))
+	case float32Kind:
+		cdivSlice32(f32(out), f32(a), toFloat[float32](c))
+	default:
+		cdivSliceGo(out, a, c)
+	}
+}
+
+// cmulSlice will return c * values of the array
+// Assumptions the assembly can make:
+// out != nil, a != nil
+// len(out)  == len(a)
+func cmulSlice[T Elem](out, a []T, c T) {
+	switch kindOf[T]() {
+	case float64Kind:
+		cmulSlice64(f64(out), f64(a), toFloat[float64](c))
+	case float32Kind:
+		cmulSlice32(f32(out), f32(a), toFloat[float32](c))
+	default:
+		cmulSliceGo(out, a, c)
+	}
+}
+
+// caddSlice will return c + values of the array
+// Assumptions the assembly can make:
+// out != nil, a != nil
+// len(out)  == len(a)
+func caddSlice[T Elem](out, a []T, c T) {
+	switch kindOf[T]() {
+	case float64Kind:
+		caddSlice64(f64(out), f64(a), toFloat[float64](c))
+	case float32Kind:
+		caddSlice32(f32(out), f32(a), toFloat[float32](c))
+	default:
+		caddSliceGo(out, a, c)
+	}
+}
+
+// addScaledSlice adds a scaled narray elementwise.
+// y = y + a * x
+// Assumptions the assembly can make:
+// y != nil, a != nil
+// len(x)  == len(y)
+func addScaledSlice[T Elem](y, x []T, a T) {
+	switch kindOf[T]() {
+	case float64Kind:
+		addScaledSlice64(f64(y), f64(x), toFloat[float64](a))
+	case float32Kind:
+		addScaledSlice32(f32(y), f32(x), toFloat[float32](a))
+	default:
+		addScaledSliceGo(y, x, a)
+	}
+}
+
+// sqrtSlice will return math.Sqrt(values) of the array
+// Assumptions the assembly can make:
+// out != nil, a != nil
+// len(out)  == len(a)
+func sqrtSlice[T Float](out, a []T) {
+	if kindOf[T]().is64() {
+		sqrtSlice64(f64(out), f64(a))
+		return
+	}
+	sqrtSlice32(f32(out), f32(a))
+}
+
+// absSlice will return math.Abs(values) of the array
+// Assumptions the assembly can make:
+// out != nil, a != nil
+// len(out)  == len(a)
+func absSlice[T Float](out, a []T) {
+	if kindOf[T]().is64() {
+		absSlice64(f64(out), f64(a))
+		return
+	}
+	absSlice32(f32(out), f32(a))
+}
+
+// minSliceElement will the smallest value of the slice
+// Assumptions the assembly can make:
+// a != nil
+// len(a) > 0
+func minSliceElement[T Float](a []T) T {
+	if kindOf[T]().is64() {
+		return T(minSliceElement64(f64(a)))
+	}
+	return T(minSliceElement32(f32(a)))
+}
+
+// maxSliceElement will the biggest value of the slice
+// Assumptions the assembly can make:
+// a != nil
+// len(a) > 0
+func maxSliceElement[T Float](a []T) T {
+	if kindOf[T]().is64() {
+		return T(maxSliceElement64(f64(a)))
+	}
+	return T(maxSliceElement32(f32(a)))
+}
+
+// sliceSum will return the sum of all elements of the slice
+// Assumptions the assembly can make:
+// a != nil
+// len(a) >= 0
+func sliceSum[T Elem](a []T) T {
+	switch kindOf[T]() {
+	case float64Kind:
+		return fromFloat[T](sliceSum64(f64(a)))
+	case float32Kind:
+		return fromFloat[T](sliceSum32(f32(a)))
+	}
+	return sliceSumGo(a)
+}
+
+// These are the fallbacks that are used when not on AMD64 platform.
+// They are also used to test the assembly routines.
+
+func divSliceGo[T Elem](out, a, b []T) {
+	for i := 0; i < len(out); i++ {
+		out[i] = a[i] / b[i]
+	}
+}
+
+func addSliceGo[T Elem](out, a, b []T) {
+	for i := 0; i < len(out); i++ {
+		out[i] = a[i] + b[i]
+	}
+}
+
+func subSliceGo[T Elem](out, a, b []T) {
+	for i := 0; i < len(out); i++ {
+		out[i] = a[i] - b[i]
+	}
+}
+
+func mulSliceGo[T Elem](out, a, b []T) {
+	for i := 0; i < len(out); i++ {
+		out[i] = a[i] * b[i]
+	}
+}
+
+func minSliceGo[T Real](out, a, b []T) {
+	for i := 0; i < len(out); i++ {
+		if a[i] < b[i] {
+			out[i] = a[i]
+		} else {
+			out[i] = b[i]
+		}
+	}
+}
+
+func maxSliceGo[T Real](out, a, b []T) {
+	for i := 0; i < len(out); i++ {
+		if a[i] > b[i] {
+			out[i] = a[i]
+		} else {
+			out[i] = b[i]
+		}
+	}
+}
+
+func csignSliceGo[T Float](out, a, b []T) {
+	for i := 0; i < len(out); i++ {
+		out[i] = T(math.Copysign(float64(a[i]), float64(b[i])))
+	}
+}
+
+func cdivSliceGo[T Elem](out, a []T, c T) {
+	for i := 0; i < len(out); i++ {
+		out[i] = c / a[i]
+	}
+}
+
+func cmulSliceGo[T Elem](out, a []T, c T) {
+	for i := 0; i < len(out); i++ {
+		out[i] = c * a[i]
+	}
+}
+
+func caddSliceGo[T Elem](out, a []T, c T) {
+	for i := 0; i < len(out); i++ {
+		out[i] = c + a[i]
+	}
+}
+
+func addScaledSliceGo[T Elem](y, x []T, a T) {
+	for i, v := range x {
+		y[i] += v * a
+	}
+}
+
+func sqrtSliceGo[T Float](out, a []T) {
+	for i := 0; i < len(out); i++ {
+		out[i] = T(math.Sqrt(float64(a[i])))
+	}
+}
+
+func absSliceGo[T Float](out, a []T) {
+	for i, v := range a {
+		out[i] = T(math.Abs(float64(v)))
+	}
+}
+
+func minSliceElementGo[T Float](a []T) T {
+	min := a[0]
+	for i := 1; i < len(a); i++ {
+		if a[i] < min {
+			min = a[i]
+		}
+	}
+	return min
+}
+
+func maxSliceElementGo[T Float](a []T) T {
+	max := a[0]
+	for i := 1; i < len(a); i++ {
+		if a[i] > max {
+			max = a[i]
+		}
+	}
+	return max
+}
+
+func sliceSumGo[T Elem](a []T) T {
+	sum := T(0.0)
+	for _, v := range a {
+		sum += v
+	}
+	return sum
+}
@@ -1,6 +1,6 @@
 
-// func divSlice(out []float32, a []float32, b []float32)
-TEXT ·divSlice(SB), 7, $0
+// func divSlice32(out []float32, a []float32, b []float32)
+TEXT ·divSlice32(SB), 7, $0
     MOVQ    out+0(FP),SI        // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -42,8 +42,8 @@ done_div:
 
 
 
-// func mulSlice(out []float32, a []float32, b []float32)
-TEXT ·mulSlice(SB), 7, $0
+// func mulSlice32(out []float32, a []float32, b []float32)
+TEXT ·mulSlice32(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -84,8 +84,8 @@ done_mul:
     RET
 
 
-// func addSlice(out []float32, a []float32, b []float32)
-TEXT ·addSlice(SB), 7, $0
+// func addSlice32(out []float32, a []float32, b []float32)
+TEXT ·addSlice32(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -125,8 +125,8 @@ onemore_add:
 done_add:
     RET
 
-// func subSlice(out []float32, a []float32, b []float32)
-TEXT ·subSlice(SB), 7, $0
+// func subSlice32(out []float32, a []float32, b []float32)
+TEXT ·subSlice32(SB), 7, $0
     MOVQ    out+0(FP),SI        // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -166,8 +166,8 @@ onemore_sub:
 done_sub:
     RET
 
-// func minSlice(out []float32, a []float32, b []float32)
-TEXT ·minSlice(SB), 7, $0
+// func minSlice32(out []float32, a []float32, b []float32)
+TEXT ·minSlice32(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -207,8 +207,8 @@ onemore_min:
 done_min:
     RET
 
-// func maxSlice(out []float32, a []float32, b []float32)
-TEXT ·maxSlice(SB), 7, $0
+// func maxSlice32(out []float32, a []float32, b []float32)
+TEXT ·maxSlice32(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -249,14 +249,14 @@ done_max:
     RET
 
 
-// func csignSlice(out []float64, a []float64, b []float64)
-TEXT ·csignSlice(SB), 7, $0
+// func csignSlice32(out []float32, a []float32, b []float32)
+TEXT ·csignSlice32(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
     MOVQ    b+48(FP),R9         // R9: &b
     MOVQ    DX, R10             // R10: len(out)
-    MOVQ    $(1<<63), BX
+    MOVQ    $(1<<31), BX
     MOVQ    BX, X4             // X4: Sign
     SHUFPS  $0, X4, X4
     SHRQ    $3, DX              // DX: len(out) / 8
@@ -303,8 +303,8 @@ onemore_csign:
 done_csign:
     RET
 
-// func cdivSlice(out []float32, a []float32, c float32)
-TEXT ·cdivSlice(SB), 7, $0
+// func cdivSlice32(out []float32, a []float32, c float32)
+TEXT ·cdivSlice32(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -344,8 +344,8 @@ done_cdiv:
     RET
 
 
-// func cmulSlice(out []float32, a []float32, c float32)
-TEXT ·cmulSlice(SB), 7, $0
+// func cmulSlice32(out []float32, a []float32, c float32)
+TEXT ·cmulSlice32(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -382,8 +382,8 @@ done_cmul:
     RET
 
 
-// func caddSlice(out []float32, a []float32, c float32)
-TEXT ·caddSlice(SB), 7, $0
+// func caddSlice32(out []float32, a []float32, c float32)
+TEXT ·caddSlice32(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -420,8 +420,8 @@ done_cadd:
     RET
 
 
-// func addScaledSlice(y []float32, x []float32, a float32)
-TEXT ·addScaledSlice(SB), 7, $0
+// func addScaledSlice32(y []float32, x []float32, a float32)
+TEXT ·addScaledSlice32(SB), 7, $0
     MOVQ    y(FP),SI            // SI: &y
     MOVQ    y_len+8(FP),DX      // DX: len(y)
     MOVQ    x+24(FP),R11        // R11: &x
@@ -463,8 +463,8 @@ onemore_madd:
 done_madd:
     RET
 
-// func sqrtSlice(out []float32, a []float32)
-TEXT ·sqrtSlice(SB), 7, $0
+// func sqrtSlice32(out []float32, a []float32)
+TEXT ·sqrtSlice32(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -498,8 +498,8 @@ onemore_sqrt:
 done_sqrt:
     RET
 
-// func absSlice(out []float32, a []float32)
-TEXT ·absSlice(SB), 7, $0
+// func absSlice32(out []float32, a []float32)
+TEXT ·absSlice32(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -539,8 +539,8 @@ onemore_abs:
 done_abs:
     RET
 
-// func minSliceElement(a []float32) float32
-TEXT ·minSliceElement(SB), 7, $0
+// func minSliceElement32(a []float32) float32
+TEXT ·minSliceElement32(SB), 7, $0
     MOVQ    a(FP),SI          // SI: &a
     MOVQ    a_len+8(FP),DX    // DX: len(a)
     MOVSS   (SI), X0          // Initial value
@@ -586,8 +586,8 @@ done_min_e:
     RET
 
 
-// func maxSliceElement(a []float32) float32
-TEXT ·maxSliceElement(SB), 7, $0
+// func maxSliceElement32(a []float32) float32
+TEXT ·maxSliceElement32(SB), 7, $0
     MOVQ    a(FP),SI          // SI: &a
     MOVQ    a_len+8(FP),DX    // DX: len(a)
     MOVSS   (SI), X0          // Initial value
@@ -634,8 +634,8 @@ done_max_e:
 
 
 
-// func sliceSum(a []float32) float32
-TEXT ·sliceSum(SB), 7, $0
+// func sliceSum32(a []float32) float32
+TEXT ·sliceSum32(SB), 7, $0
     MOVQ    a(FP),SI          // SI: &a
     MOVQ    a_len+8(FP),DX    // DX: len(a)
     XORPS   X0, X0            // Sum 1
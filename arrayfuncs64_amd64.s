@@ -1,6 +1,6 @@
 
-// func divSlice(out []float64, a []float64, b []float64)
-TEXT ·divSlice(SB), 7, $0
+// func divSlice64(out []float64, a []float64, b []float64)
+TEXT ·divSlice64(SB), 7, $0
     MOVQ    out+0(FP),SI        // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -41,8 +41,8 @@ done_div:
     RET
 
 
-// func subSlice(out []float64, a []float64, b []float64)
-TEXT ·subSlice(SB), 7, $0
+// func subSlice64(out []float64, a []float64, b []float64)
+TEXT ·subSlice64(SB), 7, $0
     MOVQ    out+0(FP),SI        // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -82,8 +82,8 @@ onemore_sub:
 done_sub:
     RET
 
-// func mulSlice(out []float64, a []float64, b []float64)
-TEXT ·mulSlice(SB), 7, $0
+// func mulSlice64(out []float64, a []float64, b []float64)
+TEXT ·mulSlice64(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -124,8 +124,8 @@ done_mul:
     RET
 
 
-// func addSlice(out []float64, a []float64, b []float64)
-TEXT ·addSlice(SB), 7, $0
+// func addSlice64(out []float64, a []float64, b []float64)
+TEXT ·addSlice64(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -165,8 +165,8 @@ onemore_add:
 done_add:
     RET
 
-// func minSlice(out []float64, a []float64, b []float64)
-TEXT ·minSlice(SB), 7, $0
+// func minSlice64(out []float64, a []float64, b []float64)
+TEXT ·minSlice64(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -206,8 +206,8 @@ onemore_min:
 done_min:
     RET
 
-// func maxSlice(out []float64, a []float64, b []float64)
-TEXT ·maxSlice(SB), 7, $0
+// func maxSlice64(out []float64, a []float64, b []float64)
+TEXT ·maxSlice64(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -247,8 +247,8 @@ onemore_max:
 done_max:
     RET
 
-// func csignSlice(out []float64, a []float64, b []float64)
-TEXT ·csignSlice(SB), 7, $0
+// func csignSlice64(out []float64, a []float64, b []float64)
+TEXT ·csignSlice64(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -301,8 +301,8 @@ onemore_csign:
 done_csign:
     RET
 
-// func cdivSlice(out []float64, a []float64, c float64)
-TEXT ·cdivSlice(SB), 7, $0
+// func cdivSlice64(out []float64, a []float64, c float64)
+TEXT ·cdivSlice64(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -342,8 +342,8 @@ done_cdiv:
     RET
 
 
-// func cmulSlice(out []float64, a []float64, c float64)
-TEXT ·cmulSlice(SB), 7, $0
+// func cmulSlice64(out []float64, a []float64, c float64)
+TEXT ·cmulSlice64(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -380,8 +380,8 @@ done_cmul:
     RET
 
 
-// func caddSlice(out []float64, a []float64, c float64)
-TEXT ·caddSlice(SB), 7, $0
+// func caddSlice64(out []float64, a []float64, c float64)
+TEXT ·caddSlice64(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -418,8 +418,8 @@ done_cadd:
     RET
 
 
-// func addScaledSlice(y []float64, x []float64, a float64)
-TEXT ·addScaledSlice(SB), 7, $0
+// func addScaledSlice64(y []float64, x []float64, a float64)
+TEXT ·addScaledSlice64(SB), 7, $0
     MOVQ    y(FP),SI            // SI: &y
     MOVQ    y_len+8(FP),DX      // DX: len(y)
     MOVQ    x+24(FP),R11        // R11: &x
@@ -461,8 +461,8 @@ onemore_madd:
 done_madd:
     RET
 
-// func sqrtSlice(out []float64, a []float64)
-TEXT ·sqrtSlice(SB), 7, $0
+// func sqrtSlice64(out []float64, a []float64)
+TEXT ·sqrtSlice64(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -496,8 +496,8 @@ onemore_sqrt:
 done_sqrt:
     RET
 
-// func absSlice(out []float64, a []float64)
-TEXT ·absSlice(SB), 7, $0
+// func absSlice64(out []float64, a []float64)
+TEXT ·absSlice64(SB), 7, $0
     MOVQ    out(FP),SI          // SI: &out
     MOVQ    out_len+8(FP),DX    // DX: len(out)
     MOVQ    a+24(FP),R11        // R11: &a
@@ -537,8 +537,8 @@ onemore_abs:
 done_abs:
     RET
 
-// func minSliceElement(a []float64) float64
-TEXT ·minSliceElement(SB), 7, $0
+// func minSliceElement64(a []float64) float64
+TEXT ·minSliceElement64(SB), 7, $0
     MOVQ    a(FP),SI          // SI: &a
     MOVQ    a_len+8(FP),DX    // DX: len(a)
     MOVSD   (SI), X0          // Initial value
@@ -577,8 +577,8 @@ done_min_e:
     RET
 
 
-// func maxSliceElement(a []float64) float64
-TEXT ·maxSliceElement(SB), 7, $0
+// func maxSliceElement64(a []float64) float64
+TEXT ·maxSliceElement64(SB), 7, $0
     MOVQ    a(FP),SI          // SI: &a
     MOVQ    a_len+8(FP),DX    // DX: len(a)
     MOVSD   (SI), X0          // Initial value
@@ -618,8 +618,8 @@ done_max_e:
 
 
 
-// func sliceSum(a []float64) float64
-TEXT ·sliceSum(SB), 7, $0
+// func sliceSum64(a []float64) float64
+TEXT ·sliceSum64(SB), 7, $0
     MOVQ    a(FP),SI          // SI: &a
     MOVQ    a_len+8(FP),DX    // DX: len(a)
     XORPD   X0, X0            // Initial value
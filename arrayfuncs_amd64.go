@@ -0,0 +1,107 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build amd64
+
+package narray
+
+// These are function definitions for AMD64 optimized routines.
+// See function documentation in arrayfuncs.go
+
+// approx 2x faster than Go
+func divSlice64(out, a, b []float64)
+
+// approx 3x faster than Go
+func addSlice64(out, a, b []float64)
+
+// approx 3x faster than Go
+func mulSlice64(out, a, b []float64)
+
+// approx 3x faster than Go
+func subSlice64(out, a, b []float64)
+
+// approx 4x faster than Go
+func minSlice64(out, a, b []float64)
+
+// approx 4x faster than Go
+func maxSlice64(out, a, b []float64)
+
+// approx Xx faster than Go
+func csignSlice64(out, a, b []float64)
+
+// approx 2x faster than Go
+func cdivSlice64(out, a []float64, c float64)
+
+// approx 3x faster than Go
+func cmulSlice64(out, a []float64, c float64)
+
+// approx 3x faster than Go
+func caddSlice64(out, a []float64, c float64)
+
+// approx 3x faster than Go
+func addScaledSlice64(y, x []float64, a float64)
+
+// approx 2x faster than Go
+func sqrtSlice64(out, a []float64)
+
+// approx 12x faster than Go
+func absSlice64(out, a []float64)
+
+// approx 6x faster than Go
+func minSliceElement64(a []float64) float64
+
+// approx 6x faster than Go
+func maxSliceElement64(a []float64) float64
+
+// approx 4x faster than Go
+func sliceSum64(a []float64) float64
+
+// approx 2x faster than Go
+func divSlice32(out, a, b []float32)
+
+// approx 3x faster than Go
+func addSlice32(out, a, b []float32)
+
+// approx 3x faster than Go
+func mulSlice32(out, a, b []float32)
+
+// approx 3x faster than Go
+func subSlice32(out, a, b []float32)
+
+// approx 4x faster than Go
+func minSlice32(out, a, b []float32)
+
+// approx 4x faster than Go
+func maxSlice32(out, a, b []float32)
+
+// approx Xx faster than Go
+func csignSlice32(out, a, b []float32)
+
+// approx 2x faster than Go
+func cdivSlice32(out, a []float32, c float32)
+
+// approx 3x faster than Go
+func cmulSlice32(out, a []float32, c float32)
+
+// approx 3x faster than Go
+func caddSlice32(out, a []float32, c float32)
+
+// approx 3x faster than Go
+func addScaledSlice32(y, x []float32, a float32)
+
+// approx 2x faster than Go
+func sqrtSlice32(out, a []float32)
+
+// approx 12x faster than Go
+func absSlice32(out, a []float32)
+
+// approx 6x faster than Go
+func minSliceElement32(a []float32) float32
+
+// approx 6x faster than Go
+func maxSliceElement32(a []float32) float32
+
+// approx 4x faster than Go
+func sliceSum32(a []float32) float32
@@ -0,0 +1,138 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !amd64
+
+package narray
+
+// Without assembly support the kernels call the generic Go fallbacks.
+
+func divSlice64(out, a, b []float64) {
+	divSliceGo(out, a, b)
+}
+
+func addSlice64(out, a, b []float64) {
+	addSliceGo(out, a, b)
+}
+
+func mulSlice64(out, a, b []float64) {
+	mulSliceGo(out, a, b)
+}
+
+func subSlice64(out, a, b []float64) {
+	subSliceGo(out, a, b)
+}
+
+func minSlice64(out, a, b []float64) {
+	minSliceGo(out, a, b)
+}
+
+func maxSlice64(out, a, b []float64) {
+	maxSliceGo(out, a, b)
+}
+
+func csignSlice64(out, a, b []float64) {
+	csignSliceGo(out, a, b)
+}
+
+func cdivSlice64(out, a []float64, c float64) {
+	cdivSliceGo(out, a, c)
+}
+
+func cmulSlice64(out, a []float64, c float64) {
+	cmulSliceGo(out, a, c)
+}
+
+func caddSlice64(out, a []float64, c float64) {
+	caddSliceGo(out, a, c)
+}
+
+func addScaledSlice64(y, x []float64, a float64) {
+	addScaledSliceGo(y, x, a)
+}
+
+func sqrtSlice64(out, a []float64) {
+	sqrtSliceGo(out, a)
+}
+
+func absSlice64(out, a []float64) {
+	absSliceGo(out, a)
+}
+
+func minSliceElement64(a []float64) float64 {
+	return minSliceElementGo(a)
+}
+
+func maxSliceElement64(a []float64) float64 {
+	return maxSliceElementGo(a)
+}
+
+func sliceSum64(a []float64) float64 {
+	return sliceSumGo(a)
+}
+
+func divSlice32(out, a, b []float32) {
+	divSliceGo(out, a, b)
+}
+
+func addSlice32(out, a, b []float32) {
+	addSliceGo(out, a, b)
+}
+
+func mulSlice32(out, a, b []float32) {
+	mulSliceGo(out, a, b)
+}
+
+func subSlice32(out, a, b []float32) {
+	subSliceGo(out, a, b)
+}
+
+func minSlice32(out, a, b []float32) {
+	minSliceGo(out, a, b)
+}
+
+func maxSlice32(out, a, b []float32) {
+	maxSliceGo(out, a, b)
+}
+
+func csignSlice32(out, a, b []float32) {
+	csignSliceGo(out, a, b)
+}
+
+func cdivSlice32(out, a []float32, c float32) {
+	cdivSliceGo(out, a, c)
+}
+
+func cmulSlice32(out, a []float32, c float32) {
+	cmulSliceGo(out, a, c)
+}
+
+func caddSlice32(out, a []float32, c float32) {
+	caddSliceGo(out, a, c)
+}
+
+func addScaledSlice32(y, x []float32, a float32) {
+	addScaledSliceGo(y, x, a)
+}
+
+func sqrtSlice32(out, a []float32) {
+	sqrtSliceGo(out, a)
+}
+
+func absSlice32(out, a []float32) {
+	absSliceGo(out, a)
+}
+
+func minSliceElement32(a []float32) float32 {
+	return minSliceElementGo(a)
+}
+
+func maxSliceElement32(a []float32) float32 {
+	return maxSliceElementGo(a)
+}
+
+func sliceSum32(a []float32) float32 {
+	return sliceSumGo(a)
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"unsafe"
+)
+
+// The binary encoding of an narray is a header followed by the values in
+// row-major order. All the numbers are little-endian.
+//
+//	magic     [4]byte        "NARY"
+//	version   uint8          1
+//	class     uint8          'f', 'c', 'i' or 'u'
+//	size      uint8          bytes per element
+//	rank      uint8
+//	shape     [rank]uint64
+//	data      values, size bytes each
+//
+// The class and the size are the dtype codes of NumPy, "f8" for float64.
+// Inf and NaN values are stored as is.
+const (
+	binaryMagic   = "NARY"
+	binaryVersion = 1
+)
+
+// littleEndian is true if the platform stores numbers in little-endian order.
+var littleEndian = func() bool {
+	v := uint16(1)
+	return *(*byte)(unsafe.Pointer(&v)) == 1
+}()
+
+// WriteTo writes the binary encoding of the narray to w. Strided views are
+// written in row-major order. Implements the io.WriterTo interface.
+func (na *NArray[T]) WriteTo(w io.Writer) (int64, error) {
+
+	if na.Rank > math.MaxUint8 {
+		return 0, fmt.Errorf("narray: can't encode rank [%d]", na.Rank)
+	}
+	descr := npyDescr[kindOf[T]()][1:]
+	var hdr bytes.Buffer
+	hdr.WriteString(binaryMagic)
+	hdr.Write([]byte{binaryVersion, descr[0], byte(binarySize(descr)), byte(na.Rank)})
+	for _, d := range na.Shape {
+		binary.Write(&hdr, binary.LittleEndian, uint64(d))
+	}
+	cw := &countWriter{w: w}
+	if _, err := cw.Write(hdr.Bytes()); err != nil {
+		return cw.n, err
+	}
+	err := writeLittle(cw, na.Contiguous().data())
+	return cw.n, err
+}
+
+// ReadFrom reads the binary encoding of an narray from r into na, as
+// written by WriteTo. Values of another type are converted to the element
+// type like ReadNPY does. Implements the io.ReaderFrom interface but, unlike
+// most implementations, reads a single narray and not until EOF.
+func (na *NArray[T]) ReadFrom(r io.Reader) (int64, error) {
+	return na.readFrom(r, -1)
+}
+
+// readFrom reads the binary encoding like ReadFrom. If max >= 0, returns an
+// error before reading the data if the encoding is longer than max bytes.
+func (na *NArray[T]) readFrom(r io.Reader, max int64) (int64, error) {
+
+	cr := &countReader{r: r}
+	hdr := make([]byte, len(binaryMagic)+4, len(binaryMagic)+4)
+	if _, err := io.ReadFull(cr, hdr); err != nil {
+		return cr.n, fmt.Errorf("narray: can't read header: %v", err)
+	}
+	if string(hdr[:len(binaryMagic)]) != binaryMagic {
+		return cr.n, fmt.Errorf("narray: invalid binary encoding")
+	}
+	hdr = hdr[len(binaryMagic):]
+	if hdr[0] != binaryVersion {
+		return cr.n, fmt.Errorf("narray: unsupported binary encoding version [%d]", hdr[0])
+	}
+	descr := fmt.Sprintf("%c%d", hdr[1], hdr[2])
+	typ, ok := npyTypes[descr]
+	if !ok || descr == "b1" {
+		return cr.n, fmt.Errorf("narray: unsupported dtype %q", descr)
+	}
+	shape := make([]int, hdr[3], hdr[3])
+	size := int(typ.Size())
+	for i := range shape {
+		var d uint64
+		if err := binary.Read(cr, binary.LittleEndian, &d); err != nil {
+			return cr.n, fmt.Errorf("narray: can't read shape: %v", err)
+		}
+		if d > math.MaxInt || (d > 0 && size > math.MaxInt/int(d)) {
+			return cr.n, fmt.Errorf("narray: invalid shape dimension [%d]", d)
+		}
+		shape[i] = int(d)
+		size *= int(d)
+	}
+	if max >= 0 && int64(size) > max-cr.n {
+		return cr.n, fmt.Errorf("narray: shape %v doesn't fit in [%d] bytes", shape, max)
+	}
+
+	// Read the data before allocating the narray, so that the memory used
+	// is bounded by the size of the input and not by the header.
+	data, err := readBounded(cr, int64(size))
+	if err != nil {
+		return cr.n, fmt.Errorf("narray: can't read data: %v", err)
+	}
+	res := New[T](shape...)
+	if err := decodeValues(res.Data, data, typ, binary.LittleEndian); err != nil {
+		return cr.n, fmt.Errorf("narray: can't read data: %v", err)
+	}
+	*na = *res
+	return cr.n, nil
+}
+
+// MarshalBinary returns the binary encoding of the narray, see WriteTo.
+// Implements the encoding.BinaryMarshaler interface.
+func (na *NArray[T]) MarshalBinary() ([]byte, error) {
+
+	var b bytes.Buffer
+	var v T
+	b.Grow(len(binaryMagic) + 4 + 8*na.Rank + na.Size()*int(unsafe.Sizeof(v)))
+	if _, err := na.WriteTo(&b); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the binary encoding of an narray into na, see
+// ReadFrom. Implements the encoding.BinaryUnmarshaler interface.
+func (na *NArray[T]) UnmarshalBinary(data []byte) error {
+
+	n, err := na.readFrom(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+	if n != int64(len(data)) {
+		return fmt.Errorf("narray: [%d] bytes left after binary encoding", int64(len(data))-n)
+	}
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface with the binary encoding.
+func (na *NArray[T]) GobEncode() ([]byte, error) {
+	return na.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface with the binary encoding.
+func (na *NArray[T]) GobDecode(data []byte) error {
+	return na.UnmarshalBinary(data)
+}
+
+// binarySize returns the number of bytes of a NumPy dtype code such as "c16".
+func binarySize(descr string) int {
+	return int(npyTypes[descr].Size())
+}
+
+// bytesOf returns a byte slice that shares the memory of a.
+func bytesOf[T Elem](a []T) []byte {
+	var v T
+	return unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(a))), len(a)*int(unsafe.Sizeof(v)))
+}
+
+// writeLittle writes the values of a to w in little-endian order.
+// The values are written without copies on little-endian platforms.
+func writeLittle[T Elem](w io.Writer, a []T) error {
+
+	if littleEndian {
+		_, err := w.Write(bytesOf(a))
+		return err
+	}
+	return binary.Write(w, binary.LittleEndian, a)
+}
+
+// readLittle reads values in little-endian order from r into a.
+func readLittle[T Elem](r io.Reader, a []T) error {
+
+	if littleEndian {
+		_, err := io.ReadFull(r, bytesOf(a))
+		return err
+	}
+	return binary.Read(r, binary.LittleEndian, a)
+}
+
+// readBounded reads n bytes from r. The memory used is bounded by the
+// bytes available in r and not by n. Returns io.ErrUnexpectedEOF if r has
+// less than n bytes.
+func readBounded(r io.Reader, n int64) ([]byte, error) {
+
+	data, err := io.ReadAll(io.LimitReader(r, n))
+	if err == nil && int64(len(data)) < n {
+		err = io.ErrUnexpectedEOF
+	}
+	return data, err
+}
+
+// countWriter counts the bytes written to w.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// countReader counts the bytes read from r.
+type countReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
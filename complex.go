@@ -0,0 +1,107 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import "math/cmplx"
+
+// The functions in this file convert between complex narrays and real
+// narrays. The real narrays usually have the precision of the parts of the
+// complex values, float64 for complex128 and float32 for complex64.
+// Packages nc64 and nc128 export them without the C prefix.
+
+// CComplex returns a complex narray with real part re and imaginary part im.
+//
+//	out = re + i * im
+//
+// If out is nil a new array is created.
+// Will panic if the shapes of out, re and im don't match.
+func CComplex[C Complex, F Float](out *NArray[C], re, im *NArray[F]) *NArray[C] {
+
+	if !EqualShape(re, im) {
+		panic(shapeError("Complex", "narrays must have equal shape", re, im))
+	}
+	out = convertOut(out, re, "Complex")
+	r := re.Contiguous().data()
+	i := im.Contiguous().data()
+	o := out.Contiguous()
+	od := o.data()
+	for k, v := range r {
+		od[k] = C(complex(float64(v), float64(i[k])))
+	}
+	if o != out {
+		out.scatter(o.Data)
+	}
+	return out
+}
+
+// CReal returns the real part of a complex narray.
+//
+//	out = real(in)
+//
+// If out is nil a new array is created.
+// Will panic if the shapes of out and in don't match.
+func CReal[C Complex, F Float](out *NArray[F], in *NArray[C]) *NArray[F] {
+	return convert(out, in, "Real", func(v C) F { return F(real(complex128(v))) })
+}
+
+// CImag returns the imaginary part of a complex narray.
+//
+//	out = imag(in)
+//
+// If out is nil a new array is created.
+// Will panic if the shapes of out and in don't match.
+func CImag[C Complex, F Float](out *NArray[F], in *NArray[C]) *NArray[F] {
+	return convert(out, in, "Imag", func(v C) F { return F(imag(complex128(v))) })
+}
+
+// CAbs returns the absolute value, or magnitude, of a complex narray.
+//
+//	out = cmplx.Abs(in)
+//
+// If out is nil a new array is created.
+// Will panic if the shapes of out and in don't match.
+func CAbs[C Complex, F Float](out *NArray[F], in *NArray[C]) *NArray[F] {
+	return convert(out, in, "Abs", func(v C) F { return F(cmplx.Abs(complex128(v))) })
+}
+
+// CPhase returns the phase, or argument, of a complex narray.
+// The values are in the range [-Pi, Pi].
+//
+//	out = cmplx.Phase(in)
+//
+// If out is nil a new array is created.
+// Will panic if the shapes of out and in don't match.
+func CPhase[C Complex, F Float](out *NArray[F], in *NArray[C]) *NArray[F] {
+	return convert(out, in, "Phase", func(v C) F { return F(cmplx.Phase(complex128(v))) })
+}
+
+// convert applies fn to the elements of in and writes the results to out.
+func convert[S, D Elem](out *NArray[D], in *NArray[S], op string, fn func(v S) D) *NArray[D] {
+
+	out = convertOut(out, in, op)
+	o := out.Contiguous()
+	od := o.data()
+	for k, v := range in.Contiguous().data() {
+		od[k] = fn(v)
+	}
+	if o != out {
+		out.scatter(o.Data)
+	}
+	return out
+}
+
+// convertOut returns out, or a new narray with the shape of in if out is nil.
+// Will panic if the shapes don't match.
+func convertOut[S, D Elem](out *NArray[D], in *NArray[S], op string) *NArray[D] {
+
+	if out == nil {
+		return New[D](in.Shape...)
+	}
+	if !equalShapes(out.Shape, in.Shape) {
+		panic(&ShapeError{Op: op, Msg: "narrays must have equal shape", Shapes: [][]int{out.Shape, in.Shape}})
+	}
+	return out
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package {{.Package}}
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/akualab/narray/{{.Real}}"
+)
+
+var c23 = NewArray([]{{.Format}}{1 + 2i, -3 + 1i, 0.5i, 2, -1 - 1i, 4 - 2i}, 2, 3)
+
+func TestArithmetic(t *testing.T) {
+
+	one := NewArray([]{{.Format}}{1i}, 1)
+	sum := Add(nil, c23, one)
+	prod := Mul(nil, c23, one)
+	diff := Sub(nil, sum, one)
+	quot := Div(nil, prod, one)
+	for k, v := range c23.Data {
+		if sum.Data[k] != v+1i {
+			t.Fatalf("add: expected %v, got %v", v+1i, sum.Data[k])
+		}
+		if prod.Data[k] != v*1i {
+			t.Fatalf("mul: expected %v, got %v", v*1i, prod.Data[k])
+		}
+		if diff.Data[k] != v {
+			t.Fatalf("sub: expected %v, got %v", v, diff.Data[k])
+		}
+		if quot.Data[k] != v {
+			t.Fatalf("div: expected %v, got %v", v, quot.Data[k])
+		}
+	}
+
+	sc := Scale(nil, c23, 2-1i)
+	ac := AddConst(nil, c23, 2-1i)
+	for k, v := range c23.Data {
+		if sc.Data[k] != v*(2-1i) {
+			t.Fatalf("scale: expected %v, got %v", v*(2-1i), sc.Data[k])
+		}
+		if ac.Data[k] != v+(2-1i) {
+			t.Fatalf("add const: expected %v, got %v", v+(2-1i), ac.Data[k])
+		}
+	}
+	if s := c23.Sum(); s != 3+0.5i {
+		t.Fatalf("sum: expected %v, got %v", 3+0.5i, s)
+	}
+}
+
+func TestParts(t *testing.T) {
+
+	re := Real(nil, c23)
+	im := Imag(nil, c23)
+	if re.Data[1] != -3 || im.Data[1] != 1 {
+		t.Fatalf("expected -3, 1 got %f, %f", re.Data[1], im.Data[1])
+	}
+	c := Complex(nil, re, im)
+	if !EqualValues(c, c23, 0) {
+		t.Fatalf("expected %v, got %v", c23, c)
+	}
+
+	abs := Abs(nil, c23)
+	phase := Phase(nil, c23)
+	for k, v := range c23.Data {
+		if math.Abs(float64(abs.Data[k])-cmplx.Abs(complex128(v))) > 0.0001 {
+			t.Fatalf("abs: expected %f, got %f", cmplx.Abs(complex128(v)), abs.Data[k])
+		}
+		if math.Abs(float64(phase.Data[k])-cmplx.Phase(complex128(v))) > 0.0001 {
+			t.Fatalf("phase: expected %f, got %f", cmplx.Phase(complex128(v)), phase.Data[k])
+		}
+	}
+
+	// Strided output.
+	out := {{.Real}}.New(3, 2)
+	Real(out.Transpose(), c23)
+	if out.At(2, 1) != 4 || out.At(0, 1) != 2 {
+		t.Fatalf("expected transposed real part, got %v", out)
+	}
+
+	if !panics(func() { Real({{.Real}}.New(3), c23) }) {
+		t.Fatalf("expected panic for shape mismatch")
+	}
+	if !panics(func() { Complex(nil, re, {{.Real}}.New(3)) }) {
+		t.Fatalf("expected panic for shape mismatch")
+	}
+}
+
+func TestMath(t *testing.T) {
+
+	conj := Conj(nil, c23)
+	exp := Exp(nil, c23)
+	sqrt := Sqrt(nil, c23)
+	pow := Pow(nil, c23, NewArray([]{{.Format}}{2}, 1))
+	for k, v := range c23.Data {
+		if conj.Data[k] != {{.Format}}(cmplx.Conj(complex128(v))) {
+			t.Fatalf("conj: expected %v, got %v", cmplx.Conj(complex128(v)), conj.Data[k])
+		}
+		if cmplx.Abs(complex128(exp.Data[k])-cmplx.Exp(complex128(v))) > 0.0001 {
+			t.Fatalf("exp: expected %v, got %v", cmplx.Exp(complex128(v)), exp.Data[k])
+		}
+		if cmplx.Abs(complex128(sqrt.Data[k]*sqrt.Data[k]-v)) > 0.0001 {
+			t.Fatalf("sqrt: expected square %v, got %v", v, sqrt.Data[k]*sqrt.Data[k])
+		}
+		if cmplx.Abs(complex128(pow.Data[k]-v*v)) > 0.0001 {
+			t.Fatalf("pow: expected %v, got %v", v*v, pow.Data[k])
+		}
+	}
+}
+
+func TestMatMul(t *testing.T) {
+
+	v := NewArray([]{{.Format}}{1i, 1, -1i}, 3)
+	out := MatMul(nil, c23, v)
+	for i := 0; i < 2; i++ {
+		var exp {{.Format}}
+		for k := 0; k < 3; k++ {
+			exp += c23.At(i, k) * v.At(k)
+		}
+		if out.At(i) != exp {
+			t.Fatalf("expected %v, got %v", exp, out.At(i))
+		}
+	}
+	gram := MatMul(nil, c23, Conj(nil, c23.Transpose()))
+	for i := 0; i < 2; i++ {
+		if imag(gram.At(i, i)) != 0 || real(gram.At(i, i)) <= 0 {
+			t.Fatalf("expected positive real diagonal, got %v", gram.At(i, i))
+		}
+	}
+}
+
+func TestOrder(t *testing.T) {
+
+	if v, idx := c23.MaxIdx(); v != 4-2i || idx[0] != 1 || idx[1] != 2 {
+		t.Fatalf("expected max 4-2i at [1 2], got %v at %v", v, idx)
+	}
+	if v := c23.Min(); v != -3+1i {
+		t.Fatalf("expected min -3+1i, got %v", v)
+	}
+	// Equal real parts are ordered by imaginary part.
+	na := NewArray([]{{.Format}}{1 + 1i, 1 - 1i, 1 + 3i}, 3)
+	if v, idx := na.MinIdx(); v != 1-1i || idx[0] != 1 {
+		t.Fatalf("expected min 1-1i at [1], got %v at %v", v, idx)
+	}
+	if v := na.Max(); v != 1+3i {
+		t.Fatalf("expected max 1+3i, got %v", v)
+	}
+}
+
+func TestJSON(t *testing.T) {
+
+	na := c23.Copy()
+	na.Set({{.Format}}(complex(math.Inf(1), math.NaN())), 1, 1)
+	s, err := na.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(s)
+
+	var res NArray
+	if err := res.UnmarshalJSON([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	v := res.At(1, 1)
+	if !math.IsInf(float64(real(v)), 1) || !math.IsNaN(float64(imag(v))) {
+		t.Fatalf("expected (+Inf+NaNi), got %v", v)
+	}
+	res.Set(c23.At(1, 1), 1, 1)
+	if !EqualValues(&res, c23, 0) {
+		t.Fatalf("expected %v, got %v", c23, &res)
+	}
+}
+
+func panics(fun func()) (b bool) {
+	defer func() {
+		err := recover()
+		if err != nil {
+			b = true
+		}
+	}()
+	fun()
+	return
+}
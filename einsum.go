@@ -0,0 +1,320 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Einsum returns the Einstein summation of the operands described by spec.
+//
+// The spec has a list of subscripts for each operand, separated by commas,
+// and optionally "->" followed by the subscripts of the result. Each
+// subscript is a letter that labels an axis. Axes with the same label must
+// have the same size. Labels that are not in the result are summed over.
+// Without "->", the result has the labels that appear exactly once, in
+// alphabetical order. Spaces are ignored. For example:
+//
+//	spec            result
+//	---------------------------------------------------
+//	ij,jk->ik       matrix product
+//	bij,bjk->bik    batched matrix product
+//	ti,tj->ij       sum of outer products over t
+//	ij->ji          transpose
+//	ii->i           diagonal
+//	ii              trace
+//	i,i             dot product
+//	ij->            sum of all values
+//
+// Repeated labels in an operand take the diagonal. The operands are
+// contracted in pairs, choosing first the pair with the smallest result,
+// and each contraction is computed as a batched matrix product.
+// The result is a new narray that doesn't share data with the operands.
+// Complex values are not conjugated.
+//
+// Will panic if the spec is invalid, if the number of subscripts of an
+// operand doesn't match its rank or if axes with the same label have
+// different sizes.
+func Einsum[T Elem](spec string, operands ...*NArray[T]) *NArray[T] {
+
+	inputs, output := parseEinsum(spec, len(operands))
+	sizes := map[rune]int{}
+	terms := make([]einsumTerm[T], len(operands), len(operands))
+	for k, na := range operands {
+		if len(inputs[k]) != na.Rank {
+			panic(&ShapeError{Op: "Einsum", Msg: fmt.Sprintf("operand [%d] has [%d] subscripts %q", k, len(inputs[k]), string(inputs[k])), Shapes: [][]int{na.Shape}})
+		}
+		for i, c := range inputs[k] {
+			if n, ok := sizes[c]; ok && n != na.Shape[i] {
+				panic(shapeError("Einsum", fmt.Sprintf("label %q has sizes [%d] and [%d]", c, n, na.Shape[i]), operands...))
+			}
+			sizes[c] = na.Shape[i]
+		}
+		terms[k] = diagonal(na, inputs[k])
+	}
+
+	// Sum the labels that are only in one operand and not in the result.
+	for k := range terms {
+		terms[k] = sumLabels(terms[k], terms, k, output, sizes)
+	}
+	for len(terms) > 1 {
+		i, j := einsumPair(terms, output, sizes)
+		t := contract(terms[i], terms[j], without(without(terms, j), i), output, sizes)
+		terms = append(terms[:j], terms[j+1:]...)
+		terms[i] = t
+	}
+
+	t := terms[0]
+	perm := make([]int, len(output), len(output))
+	for k, c := range output {
+		perm[k] = labelIndex(t.labels, c)
+	}
+	return t.na.Permute(perm...).Copy()
+}
+
+// EinsumE returns the Einstein summation of the operands. See Einsum.
+// Returns a *ShapeError if the spec is invalid or if the operands don't
+// match the spec.
+func EinsumE[T Elem](spec string, operands ...*NArray[T]) (res *NArray[T], err error) {
+	defer catch(&err)
+	return Einsum(spec, operands...), nil
+}
+
+// einsumTerm is an operand, or an intermediate result, of Einsum
+// with the labels of its axes. The labels are unique.
+type einsumTerm[T Elem] struct {
+	na     *NArray[T]
+	labels []rune
+}
+
+// parseEinsum returns the labels of the n operands and of the result.
+// Will panic with a *ShapeError if the spec is invalid.
+func parseEinsum(spec string, n int) (inputs [][]rune, output []rune) {
+
+	invalid := func(msg string) {
+		panic(&ShapeError{Op: "Einsum", Msg: fmt.Sprintf("invalid spec %q, %s", spec, msg)})
+	}
+	s := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, spec)
+	lhs, rhs, explicit := strings.Cut(s, "->")
+	counts := map[rune]int{}
+	for _, sub := range strings.Split(lhs, ",") {
+		labels := []rune(sub)
+		for _, c := range labels {
+			if !unicode.IsLetter(c) {
+				invalid(fmt.Sprintf("unexpected character %q", c))
+			}
+			counts[c]++
+		}
+		inputs = append(inputs, labels)
+	}
+	if len(inputs) != n {
+		invalid(fmt.Sprintf("spec has [%d] operands, got [%d]", len(inputs), n))
+	}
+	if !explicit {
+		output = []rune{}
+		for c, v := range counts {
+			if v == 1 {
+				output = append(output, c)
+			}
+		}
+		sort.Slice(output, func(i, j int) bool { return output[i] < output[j] })
+		return
+	}
+	output = []rune(rhs)
+	for i, c := range output {
+		if counts[c] == 0 {
+			invalid(fmt.Sprintf("output label %q is not in the operands", c))
+		}
+		if labelIndex(output[:i], c) >= 0 {
+			invalid(fmt.Sprintf("output label %q is repeated", c))
+		}
+	}
+	return
+}
+
+// diagonal returns a view of the narray with a single axis for each
+// label. The axes with a repeated label are replaced by their diagonal.
+func diagonal[T Elem](na *NArray[T], labels []rune) einsumTerm[T] {
+
+	t := einsumTerm[T]{na: &NArray[T]{Data: na.Data, Offset: na.Offset}}
+	for i, c := range labels {
+		k := labelIndex(t.labels, c)
+		if k < 0 {
+			t.labels = append(t.labels, c)
+			t.na.Shape = append(t.na.Shape, na.Shape[i])
+			t.na.Strides = append(t.na.Strides, na.Strides[i])
+			continue
+		}
+		// Moving one step along the diagonal moves along both axes.
+		t.na.Strides[k] += na.Strides[i]
+	}
+	t.na.Rank = len(t.labels)
+	return t
+}
+
+// needed reports whether label c is in the output or in any term
+// other than term k.
+func needed[T Elem](c rune, terms []einsumTerm[T], k int, output []rune) bool {
+
+	if labelIndex(output, c) >= 0 {
+		return true
+	}
+	for i, t := range terms {
+		if i != k && labelIndex(t.labels, c) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// sumLabels sums term k over the labels that are not needed.
+// The sum is the product with a vector of ones.
+func sumLabels[T Elem](t einsumTerm[T], terms []einsumTerm[T], k int, output []rune, sizes map[rune]int) einsumTerm[T] {
+
+	var keep, drop []rune
+	for _, c := range t.labels {
+		if needed(c, terms, k, output) {
+			keep = append(keep, c)
+		} else {
+			drop = append(drop, c)
+		}
+	}
+	if len(drop) == 0 {
+		return t
+	}
+	m, s := product(keep, sizes), product(drop, sizes)
+	ones := New[T](s)
+	ones.SetValue(1)
+	a := t.na.Permute(axesOf(t, keep, drop)...).Reshape(m, s)
+	res := MatMul(nil, a, ones)
+	return einsumTerm[T]{na: res.Reshape(shapeOf(keep, sizes)...), labels: keep}
+}
+
+// einsumPair returns the pair of terms i < j whose contraction has the
+// smallest result.
+func einsumPair[T Elem](terms []einsumTerm[T], output []rune, sizes map[rune]int) (pi, pj int) {
+
+	best := -1
+	for i := range terms {
+		for j := i + 1; j < len(terms); j++ {
+			rest := without(without(terms, j), i)
+			n := 1
+			for _, c := range labelSet(terms[i].labels, terms[j].labels) {
+				if needed(c, rest, -1, output) {
+					n *= sizes[c]
+				}
+			}
+			if best < 0 || n < best {
+				best, pi, pj = n, i, j
+			}
+		}
+	}
+	return
+}
+
+// contract returns the contraction of terms a and b, where rest are the
+// other terms. The labels of the result are the batch labels, that are in
+// both terms and are still needed, followed by the free labels of a and
+// of b. The labels in both terms that are not needed anymore are summed over.
+func contract[T Elem](a, b einsumTerm[T], rest []einsumTerm[T], output []rune, sizes map[rune]int) einsumTerm[T] {
+
+	var batch, sum, freeA, freeB []rune
+	for _, c := range a.labels {
+		inB := labelIndex(b.labels, c) >= 0
+		keep := needed(c, rest, -1, output)
+		switch {
+		case inB && keep:
+			batch = append(batch, c)
+		case inB:
+			sum = append(sum, c)
+		default:
+			freeA = append(freeA, c)
+		}
+	}
+	for _, c := range b.labels {
+		if labelIndex(a.labels, c) < 0 {
+			freeB = append(freeB, c)
+		}
+	}
+
+	nb, m, k, n := product(batch, sizes), product(freeA, sizes), product(sum, sizes), product(freeB, sizes)
+	am := a.na.Permute(axesOf(a, batch, freeA, sum)...).Reshape(nb, m, k)
+	bm := b.na.Permute(axesOf(b, batch, sum, freeB)...).Reshape(nb, k, n)
+	labels := append(append(append([]rune{}, batch...), freeA...), freeB...)
+	res := MatMul(nil, am, bm)
+	return einsumTerm[T]{na: res.Reshape(shapeOf(labels, sizes)...), labels: labels}
+}
+
+// without returns the terms without term k.
+func without[T Elem](terms []einsumTerm[T], k int) []einsumTerm[T] {
+
+	res := make([]einsumTerm[T], 0, len(terms))
+	res = append(res, terms[:k]...)
+	return append(res, terms[k+1:]...)
+}
+
+// labelSet returns the labels that are in a or b.
+func labelSet(a, b []rune) []rune {
+
+	res := append([]rune{}, a...)
+	for _, c := range b {
+		if labelIndex(a, c) < 0 {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// labelIndex returns the position of label c in labels, or -1.
+func labelIndex(labels []rune, c rune) int {
+
+	for k, v := range labels {
+		if v == c {
+			return k
+		}
+	}
+	return -1
+}
+
+// axesOf returns the axes of the term for the labels in groups.
+func axesOf[T Elem](t einsumTerm[T], groups ...[]rune) []int {
+
+	axes := make([]int, 0, len(t.labels))
+	for _, g := range groups {
+		for _, c := range g {
+			axes = append(axes, labelIndex(t.labels, c))
+		}
+	}
+	return axes
+}
+
+// shapeOf returns the sizes of the labels.
+func shapeOf(labels []rune, sizes map[rune]int) []int {
+
+	shape := make([]int, len(labels), len(labels))
+	for k, c := range labels {
+		shape[k] = sizes[c]
+	}
+	return shape
+}
+
+// product returns the product of the sizes of the labels.
+func product(labels []rune, sizes map[rune]int) int {
+
+	n := 1
+	for _, c := range labels {
+		n *= sizes[c]
+	}
+	return n
+}
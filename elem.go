@@ -0,0 +1,305 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import (
+	"encoding/json"
+	"reflect"
+	"unsafe"
+)
+
+// Float is the set of real element types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Complex is the set of complex element types.
+type Complex interface {
+	~complex64 | ~complex128
+}
+
+// Integer is the set of integer element types.
+// Types int and uint are not included so that the size of
+// the elements doesn't depend on the platform.
+type Integer interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Real is the set of element types with a natural order.
+type Real interface {
+	Float | Integer
+}
+
+// Elem is the set of element types of an NArray.
+//
+// Functions that need the math package are restricted to Float and functions
+// that need an ordering are restricted to Real. Functions that only apply to
+// complex numbers are restricted to Complex and their names start with C, for
+// example CAbs. Functions that only apply to integers are restricted to Integer
+// and their names start with I, for example IAnd.
+type Elem interface {
+	Float | Complex | Integer
+}
+
+// kind identifies the underlying type of the elements of an narray.
+type kind int
+
+const (
+	float32Kind kind = iota
+	float64Kind
+	complex64Kind
+	complex128Kind
+	int8Kind
+	int16Kind
+	int32Kind
+	int64Kind
+	uint8Kind
+	uint16Kind
+	uint32Kind
+	uint64Kind
+)
+
+// kinds maps the kinds of the underlying types of named element types.
+var kinds = map[reflect.Kind]kind{
+	reflect.Float32:    float32Kind,
+	reflect.Float64:    float64Kind,
+	reflect.Complex64:  complex64Kind,
+	reflect.Complex128: complex128Kind,
+	reflect.Int8:       int8Kind,
+	reflect.Int16:      int16Kind,
+	reflect.Int32:      int32Kind,
+	reflect.Int64:      int64Kind,
+	reflect.Uint8:      uint8Kind,
+	reflect.Uint16:     uint16Kind,
+	reflect.Uint32:     uint32Kind,
+	reflect.Uint64:     uint64Kind,
+}
+
+// kindOf returns the kind of element type T.
+// Named types have the kind of their underlying type.
+func kindOf[T Elem]() kind {
+
+	switch any((*T)(nil)).(type) {
+	case *float32:
+		return float32Kind
+	case *float64:
+		return float64Kind
+	case *complex64:
+		return complex64Kind
+	case *complex128:
+		return complex128Kind
+	case *int32:
+		return int32Kind
+	case *int64:
+		return int64Kind
+	case *uint8:
+		return uint8Kind
+	}
+	return kinds[reflect.TypeOf((*T)(nil)).Elem().Kind()]
+}
+
+// is64 returns true if the elements of type T are made of float64 values.
+func (k kind) is64() bool {
+	return k == float64Kind || k == complex128Kind
+}
+
+// isComplex returns true for complex kinds.
+func (k kind) isComplex() bool {
+	return k == complex64Kind || k == complex128Kind
+}
+
+// isInteger returns true for integer kinds.
+func (k kind) isInteger() bool {
+	return k >= int8Kind
+}
+
+// parts returns the number of floats in an element of kind k.
+func (k kind) parts() int {
+	if k.isComplex() {
+		return 2
+	}
+	return 1
+}
+
+// f64 returns a float64 slice that shares the memory of a.
+// Complex values become consecutive real and imaginary parts.
+// Must only be called when kindOf[T]().is64() is true.
+func f64[T Elem](a []T) []float64 {
+	var v T
+	n := len(a) * int(unsafe.Sizeof(v)) / 8
+	return unsafe.Slice((*float64)(unsafe.Pointer(unsafe.SliceData(a))), n)
+}
+
+// f32 returns a float32 slice that shares the memory of a.
+// Complex values become consecutive real and imaginary parts.
+// Must only be called for kinds float32Kind and complex64Kind.
+func f32[T Elem](a []T) []float32 {
+	var v T
+	n := len(a) * int(unsafe.Sizeof(v)) / 4
+	return unsafe.Slice((*float32)(unsafe.Pointer(unsafe.SliceData(a))), n)
+}
+
+// fromFloat returns v as an element of type T.
+// Must only be called when T is a float type with the size of F.
+func fromFloat[T Elem, F Float](v F) T {
+	return *(*T)(unsafe.Pointer(&v))
+}
+
+// toFloat returns element v as a float of type F.
+// Must only be called when T is a float type with the size of F.
+func toFloat[F Float, T Elem](v T) F {
+	return *(*F)(unsafe.Pointer(&v))
+}
+
+// less returns true if x is less than y.
+// Complex values are ordered by real part first and then by imaginary part.
+func less[T Elem](x, y T) bool {
+
+	a := [2]T{x, y}
+	k := kindOf[T]()
+	switch {
+	case k.isInteger():
+		return intLess[T](k)(x, y)
+	case k.is64():
+		return lexLess(f64(a[:]), 0, 1, k.parts())
+	}
+	return lexLess(f32(a[:]), 0, 1, k.parts())
+}
+
+// intLess returns the function that compares elements of integer kind k.
+func intLess[T Elem](k kind) func(x, y T) bool {
+
+	switch k {
+	case int8Kind:
+		return lessAs[int8, T]
+	case int16Kind:
+		return lessAs[int16, T]
+	case int32Kind:
+		return lessAs[int32, T]
+	case int64Kind:
+		return lessAs[int64, T]
+	case uint8Kind:
+		return lessAs[uint8, T]
+	case uint16Kind:
+		return lessAs[uint16, T]
+	case uint32Kind:
+		return lessAs[uint32, T]
+	}
+	return lessAs[uint64, T]
+}
+
+// lessAs returns true if x < y, where x and y are compared as values of type I.
+// Must only be called when T has underlying type I.
+func lessAs[I Integer, T Elem](x, y T) bool {
+	return *(*I)(unsafe.Pointer(&x)) < *(*I)(unsafe.Pointer(&y))
+}
+
+// lexLess compares elements i and j of a, where each element
+// is made of n consecutive values. Returns true if element i
+// is lexicographically less than element j.
+func lexLess[F Float](a []F, i, j, n int) bool {
+
+	for p := 0; p < n; p++ {
+		x, y := a[i*n+p], a[j*n+p]
+		if x != y {
+			return x < y
+		}
+	}
+	return false
+}
+
+// argMaxSlice returns the index of the max value of a.
+// For complex values see less.
+// Assumptions:
+// len(a) > 0
+func argMaxSlice[T Elem](a []T) int {
+	return argSlice(a, true)
+}
+
+// argMinSlice returns the index of the min value of a.
+// For complex values see less.
+// Assumptions:
+// len(a) > 0
+func argMinSlice[T Elem](a []T) int {
+	return argSlice(a, false)
+}
+
+// argSlice returns the index of the max value of a, or the min value
+// when max is false.
+func argSlice[T Elem](a []T, max bool) int {
+
+	k := kindOf[T]()
+	switch {
+	case k.isInteger():
+		less := intLess[T](k)
+		best := 0
+		for i := 1; i < len(a); i++ {
+			if max && less(a[best], a[i]) || !max && less(a[i], a[best]) {
+				best = i
+			}
+		}
+		return best
+	case k.is64():
+		return argBest(f64(a), k.parts(), max)
+	}
+	return argBest(f32(a), k.parts(), max)
+}
+
+// argBest returns the index of the max element, or the min element
+// when max is false, where elements are made of n consecutive values.
+func argBest[F Float](a []F, n int, max bool) int {
+
+	best := 0
+	for i := 1; i < len(a)/n; i++ {
+		if max && lexLess(a, best, i, n) || !max && lexLess(a, i, best, n) {
+			best = i
+		}
+	}
+	return best
+}
+
+// jsonData returns the value used to encode the elements in a.
+// Complex values are encoded as [real, imag] pairs because
+// encoding/json doesn't support complex numbers. Bytes are
+// encoded as numbers instead of a base64 string.
+func jsonData[T Elem](a []T) interface{} {
+
+	switch kindOf[T]() {
+	case complex128Kind:
+		return unsafe.Slice((*[2]float64)(unsafe.Pointer(unsafe.SliceData(a))), len(a))
+	case complex64Kind:
+		return unsafe.Slice((*[2]float32)(unsafe.Pointer(unsafe.SliceData(a))), len(a))
+	case uint8Kind:
+		b := make([]uint16, len(a), len(a))
+		for k, v := range unsafe.Slice((*uint8)(unsafe.Pointer(unsafe.SliceData(a))), len(a)) {
+			b[k] = uint16(v)
+		}
+		return b
+	}
+	return a
+}
+
+// unmarshalData decodes elements encoded by jsonData.
+func unmarshalData[T Elem](b json.RawMessage) ([]T, error) {
+
+	if len(b) == 0 {
+		return nil, nil
+	}
+	var err error
+	switch kindOf[T]() {
+	case complex128Kind:
+		var p [][2]float64
+		err = json.Unmarshal(b, &p)
+		return unsafe.Slice((*T)(unsafe.Pointer(unsafe.SliceData(p))), len(p)), err
+	case complex64Kind:
+		var p [][2]float32
+		err = json.Unmarshal(b, &p)
+		return unsafe.Slice((*T)(unsafe.Pointer(unsafe.SliceData(p))), len(p)), err
+	}
+	var a []T
+	err = json.Unmarshal(b, &a)
+	return a, err
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ShapeError describes narrays whose shapes are not compatible with an operation.
+type ShapeError struct {
+	// Op is the name of the operation, it may be empty.
+	Op string
+	// Msg describes the problem.
+	Msg string
+	// Shapes are the shapes involved.
+	Shapes [][]int
+}
+
+func (e *ShapeError) Error() string {
+
+	s := e.Msg
+	if e.Op != "" {
+		s = e.Op + ": " + s
+	}
+	if len(e.Shapes) > 0 {
+		s += fmt.Sprintf(" %v", e.Shapes)
+	}
+	return s
+}
+
+// IndexError describes indices that are out of range for an narray.
+type IndexError struct {
+	// Indices is a copy of the offending indices.
+	Indices []int
+	// Shape is the shape of the narray.
+	Shape []int
+}
+
+func (e *IndexError) Error() string {
+
+	if len(e.Indices) != len(e.Shape) {
+		return fmt.Sprintf("inconsistent number of indices for narray - [%d] vs [%d]", len(e.Indices), len(e.Shape))
+	}
+	return fmt.Sprintf("indices %v out of range for narray of shape %v", e.Indices, e.Shape)
+}
+
+// ErrNotEnoughArgs is returned when an operation needs at least two input narrays.
+var ErrNotEnoughArgs = errors.New("not enough input narrays")
+
+// The checked variants below behave like the functions they are named after
+// but return an error instead of panicking when indices or shapes are invalid.
+
+// AtE returns the value for indices.
+// Returns an *IndexError if the indices are out of range.
+func (na *NArray[T]) AtE(indices ...int) (v T, err error) {
+	defer catch(&err)
+	return na.At(indices...), nil
+}
+
+// SetE sets the value for indices.
+// Returns an *IndexError if the indices are out of range.
+func (na *NArray[T]) SetE(v T, indices ...int) (err error) {
+	defer catch(&err)
+	na.Set(v, indices...)
+	return nil
+}
+
+// ReshapeE returns an narray with a new shape. See Reshape.
+// Returns a *ShapeError if the size of the new shape doesn't match.
+func (na *NArray[T]) ReshapeE(dim ...int) (res *NArray[T], err error) {
+	defer catch(&err)
+	return na.Reshape(dim...), nil
+}
+
+// AddE adds narrays elementwise. See Add.
+// Returns ErrNotEnoughArgs or a *ShapeError instead of panicking.
+func AddE[T Elem](out *NArray[T], in ...*NArray[T]) (res *NArray[T], err error) {
+	if len(in) < 2 {
+		return nil, ErrNotEnoughArgs
+	}
+	defer catch(&err)
+	return Add(out, in...), nil
+}
+
+// SubE subtracts narrays elementwise. See Sub.
+// Returns ErrNotEnoughArgs or a *ShapeError instead of panicking.
+func SubE[T Elem](out *NArray[T], in ...*NArray[T]) (res *NArray[T], err error) {
+	if len(in) < 2 {
+		return nil, ErrNotEnoughArgs
+	}
+	defer catch(&err)
+	return Sub(out, in...), nil
+}
+
+// MulE multiplies narrays elementwise. See Mul.
+// Returns ErrNotEnoughArgs or a *ShapeError instead of panicking.
+func MulE[T Elem](out *NArray[T], in ...*NArray[T]) (res *NArray[T], err error) {
+	if len(in) < 2 {
+		return nil, ErrNotEnoughArgs
+	}
+	defer catch(&err)
+	return Mul(out, in...), nil
+}
+
+// DivE divides narrays elementwise. See Div.
+// Returns ErrNotEnoughArgs or a *ShapeError instead of panicking.
+func DivE[T Elem](out *NArray[T], in ...*NArray[T]) (res *NArray[T], err error) {
+	if len(in) < 2 {
+		return nil, ErrNotEnoughArgs
+	}
+	defer catch(&err)
+	return Div(out, in...), nil
+}
+
+// shapeError returns a *ShapeError with the shapes of the narrays.
+func shapeError[T Elem](op, msg string, in ...*NArray[T]) *ShapeError {
+
+	shapes := make([][]int, len(in), len(in))
+	for k, na := range in {
+		shapes[k] = na.Shape
+	}
+	return &ShapeError{Op: op, Msg: msg, Shapes: shapes}
+}
+
+// indexError returns an *IndexError with a copy of the indices.
+func indexError(indices, shape []int) *IndexError {
+
+	idx := make([]int, len(indices), len(indices))
+	copy(idx, indices)
+	return &IndexError{Indices: idx, Shape: shape}
+}
+
+// catch recovers from a panic caused by a *ShapeError or an *IndexError
+// and stores it in err. Other panics are propagated.
+func catch(err *error) {
+
+	r := recover()
+	switch e := r.(type) {
+	case nil:
+	case *ShapeError:
+		*err = e
+	case *IndexError:
+		*err = e
+	default:
+		panic(r)
+	}
+}
+
+// SubArrayE returns a view of the narray. See SubArray.
+// Returns an *IndexError or a *ShapeError instead of panicking.
+func (na *NArray[T]) SubArrayE(query ...int) (res *NArray[T], err error) {
+	defer catch(&err)
+	return na.SubArray(query...), nil
+}
+
+// PermuteE returns a view with permuted axes. See Permute.
+// Returns a *ShapeError instead of panicking.
+func (na *NArray[T]) PermuteE(axes ...int) (res *NArray[T], err error) {
+	defer catch(&err)
+	return na.Permute(axes...), nil
+}
+
+// SliceE returns a view of the narray. See Slice.
+// Returns an *IndexError or a *ShapeError instead of panicking.
+func (na *NArray[T]) SliceE(specs ...SliceSpec) (res *NArray[T], err error) {
+	defer catch(&err)
+	return na.Slice(specs...), nil
+}
+
+// TakeE returns the elements at indices along an axis. See Take.
+// Returns an *IndexError or a *ShapeError instead of panicking.
+func (na *NArray[T]) TakeE(axis int, indices ...int) (res *NArray[T], err error) {
+	defer catch(&err)
+	return na.Take(axis, indices...), nil
+}
+
+// ConcatenateE joins narrays along an existing axis. See Concatenate.
+// Returns a *ShapeError instead of panicking.
+func ConcatenateE[T Elem](axis int, in ...*NArray[T]) (res *NArray[T], err error) {
+	defer catch(&err)
+	return Concatenate(axis, in...), nil
+}
+
+// SumAxisE sums over axes. See SumAxis.
+// Returns a *ShapeError instead of panicking.
+func SumAxisE[T Real](out, in *NArray[T], keepDims bool, axes ...int) (res *NArray[T], err error) {
+	defer catch(&err)
+	return SumAxis(out, in, keepDims, axes...), nil
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package {{.Package}}
+
+import "testing"
+
+func TestIndexBounds(t *testing.T) {
+
+	if !panics(func() { x.At(3, 0) }) {
+		t.Errorf("did not panic with index out of range")
+	}
+	if !panics(func() { x.At(0, 5) }) {
+		t.Errorf("did not panic with index out of range")
+	}
+	if !panics(func() { x.At(-1, 0) }) {
+		t.Errorf("did not panic with negative index")
+	}
+	if !panics(func() { x.At(1) }) {
+		t.Errorf("did not panic with wrong number of indices")
+	}
+	if !panics(func() { x.Index(1, 1, 0) }) {
+		t.Errorf("did not panic with wrong number of indices")
+	}
+}
+
+func TestAtE(t *testing.T) {
+
+	v, err := x.AtE(2, 4)
+	if err != nil || v != x.At(2, 4) {
+		t.Fatalf("expected %f, got %f, %v", x.At(2, 4), v, err)
+	}
+	_, err = x.AtE(2, 5)
+	if _, ok := err.(*IndexError); !ok {
+		t.Fatalf("expected *IndexError, got %v", err)
+	}
+	t.Log(err)
+	_, err = x.AtE(2)
+	if _, ok := err.(*IndexError); !ok {
+		t.Fatalf("expected *IndexError, got %v", err)
+	}
+	t.Log(err)
+
+	xx := x.Copy()
+	if err := xx.SetE(-1, 1, 1); err != nil || xx.At(1, 1) != -1 {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := xx.SetE(-1, 1, 5); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestAddE(t *testing.T) {
+
+	z, err := AddE(nil, x, y)
+	if err != nil || !EqualValues(z, Add(nil, x, y), 0) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := AddE(nil, x); err != ErrNotEnoughArgs {
+		t.Fatalf("expected ErrNotEnoughArgs, got %v", err)
+	}
+	_, err = SubE(nil, x, New(3))
+	if _, ok := err.(*ShapeError); !ok {
+		t.Fatalf("expected *ShapeError, got %v", err)
+	}
+	t.Log(err)
+	if _, err := MulE(New(2), x, y); err == nil {
+		t.Fatalf("expected error for out shape")
+	}
+	if _, err := DivE(nil, x, y, na234); err == nil {
+		t.Fatalf("expected error for incompatible shapes")
+	}
+
+	// Panicking variants are unchanged.
+	if Add(nil, x) != nil {
+		t.Fatalf("expected nil")
+	}
+	if !panics(func() { Sub(nil, x, New(3)) }) {
+		t.Errorf("did not panic with shape mismatch")
+	}
+}
+
+func TestReshapeE(t *testing.T) {
+
+	if _, err := x.ReshapeE(-1, 4); err == nil {
+		t.Fatalf("expected error")
+	} else if _, ok := err.(*ShapeError); !ok {
+		t.Fatalf("expected *ShapeError, got %v", err)
+	}
+	r, err := x.ReshapeE(5, -1)
+	if err != nil || r.Shape[1] != 3 {
+		t.Fatalf("unexpected result %v, %v", r, err)
+	}
+}
+
+func TestViewE(t *testing.T) {
+
+	var err error
+	if _, err = x.SubArrayE(3, -1); err == nil {
+		t.Fatalf("expected error")
+	}
+	if _, ok := err.(*IndexError); !ok {
+		t.Fatalf("expected *IndexError, got %v", err)
+	}
+	if _, err = x.SliceE(Pick(5)); err == nil {
+		t.Fatalf("expected error")
+	}
+	if _, err = x.TakeE(1, 0, 5); err == nil {
+		t.Fatalf("expected error")
+	}
+	if _, ok := err.(*IndexError); !ok {
+		t.Fatalf("expected *IndexError, got %v", err)
+	}
+	for _, f := range []func() (*NArray, error){
+		func() (*NArray, error) { return x.SubArrayE(-1) },
+		func() (*NArray, error) { return x.PermuteE(0, 0) },
+		func() (*NArray, error) { return x.SliceE(All, All, All) },
+		func() (*NArray, error) { return x.TakeE(2, 0) },
+		func() (*NArray, error) { return ConcatenateE(1, x, New(4, 5)) },
+		func() (*NArray, error) { return SumAxisE(nil, x, false, 2) },
+	} {
+		_, err := f()
+		switch err.(type) {
+		case *ShapeError, *IndexError:
+		default:
+			t.Fatalf("expected *ShapeError or *IndexError, got %v", err)
+		}
+	}
+	if _, err = SumAxisE(nil, x, false, 2); err.(*ShapeError).Op != "SumAxis" {
+		t.Fatalf("expected op SumAxis, got %v", err)
+	}
+	if _, err = SubE(nil, x, New(3)); err.(*ShapeError).Op != "Sub" {
+		t.Fatalf("expected op Sub, got %v", err)
+	}
+	if y, err := x.PermuteE(1, 0); err != nil || y.Shape[0] != 5 {
+		t.Fatalf("unexpected result %v, %v", y, err)
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+	"sync"
+
+	"github.com/akualab/narray"
+)
+
+// DCT computes the orthonormal discrete cosine transform of type II of in along axis.
+//
+//	out[..., k, ...] = s(k) * sum_t in[..., t, ...] * cos(pi*(2t+1)*k/(2n))
+//
+// where s(0) = sqrt(1/n) and s(k) = sqrt(2/n) otherwise. The size of the axis of
+// out may be less than n to compute only the first coefficients, as for cepstral
+// coefficients:
+//
+//	mfcc := fft.DCT(na64.New(frames, 13), logEnergies, 1)
+//
+// Short lengths are computed with a cached basis, longer lengths with an FFT
+// of the same length.
+//
+// If out is nil a new array is created with the shape of in.
+// Will panic if the axis is out of range, if the axis has size zero or if
+// the shape of out doesn't match.
+func DCT[F narray.Float](out, in *narray.NArray[F], axis int) *narray.NArray[F] {
+
+	n := axisSize(in, axis)
+	m := n
+	if out != nil && axis < out.Rank && out.Shape[axis] <= n {
+		m = out.Shape[axis]
+	}
+	out = outArray(out, in, axis, m, "DCT")
+	d := dctFor(n)
+	x := make([]float64, n, n)
+	coef := make([]float64, m, m)
+	buf, scratch := d.buffers()
+	si, so := in.Strides[axis], out.Strides[axis]
+	lanes(out, in, axis, func(o, i int) {
+		for t := range x {
+			x[t] = float64(in.Data[i+t*si])
+		}
+		d.forward(coef, x, buf, scratch)
+		for k, v := range coef {
+			out.Data[o+k*so] = F(v)
+		}
+	})
+	return out
+}
+
+// IDCT computes the orthonormal discrete cosine transform of type III of in
+// along axis, which is the inverse of DCT.
+//
+//	out[..., t, ...] = sum_k s(k) * in[..., k, ...] * cos(pi*(2t+1)*k/(2n))
+//
+// The size of the axis of out may be greater than the size of the axis of in,
+// the missing coefficients are zero.
+//
+// If out is nil a new array is created with the shape of in.
+// Will panic if the axis is out of range, if the axis has size zero or if
+// the shape of out doesn't match.
+func IDCT[F narray.Float](out, in *narray.NArray[F], axis int) *narray.NArray[F] {
+
+	m := axisSize(in, axis)
+	n := m
+	if out != nil && axis < out.Rank && out.Shape[axis] >= m {
+		n = out.Shape[axis]
+	}
+	out = outArray(out, in, axis, n, "IDCT")
+	d := dctFor(n)
+	x := make([]float64, n, n)
+	coef := make([]float64, m, m)
+	buf, scratch := d.buffers()
+	si, so := in.Strides[axis], out.Strides[axis]
+	lanes(out, in, axis, func(o, i int) {
+		for k := range coef {
+			coef[k] = float64(in.Data[i+k*si])
+		}
+		d.inverse(x, coef, buf, scratch)
+		for t, v := range x {
+			out.Data[o+t*so] = F(v)
+		}
+	})
+	return out
+}
+
+// maxDCTBasis is the largest length that uses a cached n x n basis.
+// Longer transforms use an FFT, which takes O(n log n) time and O(n) space.
+const maxDCTBasis = 64
+
+// dctPlan computes the DCT of length n, with the basis for short
+// lengths or with the FFT plan of length n otherwise.
+type dctPlan struct {
+	n int
+	// basis is the n x n row-major matrix where row k is the scaled
+	// cosine of frequency k, nil for long transforms.
+	basis []float64
+	// fft and twiddle, exp(-i*pi*k/(2n)), for long transforms.
+	fft     *Plan
+	twiddle []complex128
+}
+
+// dcts caches the plans of the short lengths.
+var dcts = struct {
+	sync.Mutex
+	m map[int]*dctPlan
+}{m: map[int]*dctPlan{}}
+
+// dctFor returns the plan of the DCT of length n. Plans of lengths up
+// to maxDCTBasis are cached, the FFT plans are cached by PlanFor.
+func dctFor(n int) *dctPlan {
+
+	if n > maxDCTBasis {
+		d := &dctPlan{n: n, fft: PlanFor(n)}
+		d.twiddle = make([]complex128, n, n)
+		for k := range d.twiddle {
+			s, c := math.Sincos(-math.Pi * float64(k) / float64(2*n))
+			d.twiddle[k] = complex(c, s)
+		}
+		return d
+	}
+
+	dcts.Lock()
+	defer dcts.Unlock()
+	if d, ok := dcts.m[n]; ok {
+		return d
+	}
+	d := &dctPlan{n: n, basis: make([]float64, n*n, n*n)}
+	for k := 0; k < n; k++ {
+		for t := 0; t < n; t++ {
+			d.basis[k*n+t] = dctScale(k, n) * math.Cos(math.Pi*float64((2*t+1)*k)/float64(2*n))
+		}
+	}
+	dcts.m[n] = d
+	return d
+}
+
+// dctScale returns s(k) for the length n.
+func dctScale(k, n int) float64 {
+	if k == 0 {
+		return math.Sqrt(1 / float64(n))
+	}
+	return math.Sqrt(2 / float64(n))
+}
+
+// buffers returns the buffers needed by forward and inverse, nil for
+// short transforms.
+func (d *dctPlan) buffers() (buf, scratch []complex128) {
+	if d.basis != nil {
+		return nil, nil
+	}
+	return make([]complex128, d.n, d.n), d.fft.scratch()
+}
+
+// forward computes the first len(coef) DCT-II coefficients of x.
+func (d *dctPlan) forward(coef, x []float64, buf, scratch []complex128) {
+
+	n := d.n
+	if d.basis != nil {
+		for k := range coef {
+			row := d.basis[k*n : k*n+n]
+			sum := 0.0
+			for t, v := range x {
+				sum += v * row[t]
+			}
+			coef[k] = sum
+		}
+		return
+	}
+
+	// Makhoul's algorithm: the even samples in order followed by the
+	// odd samples in reverse order, then an FFT of length n.
+	for t := 0; 2*t < n; t++ {
+		buf[t] = complex(x[2*t], 0)
+	}
+	for t := 0; 2*t+1 < n; t++ {
+		buf[n-1-t] = complex(x[2*t+1], 0)
+	}
+	d.fft.forward(buf, scratch)
+	for k := range coef {
+		coef[k] = dctScale(k, n) * real(d.twiddle[k]*buf[k])
+	}
+}
+
+// inverse computes x from the first len(coef) DCT-II coefficients,
+// the missing coefficients are zero.
+func (d *dctPlan) inverse(x, coef []float64, buf, scratch []complex128) {
+
+	n := d.n
+	if d.basis != nil {
+		for t := range x {
+			x[t] = 0
+		}
+		for k, v := range coef {
+			row := d.basis[k*n : k*n+n]
+			for t := range x {
+				x[t] += v * row[t]
+			}
+		}
+		return
+	}
+
+	// Unscaled coefficient k, with zeros past len(coef).
+	unscaled := func(k int) float64 {
+		if k >= len(coef) {
+			return 0
+		}
+		if k == 0 {
+			return float64(n) * dctScale(0, n) * coef[0]
+		}
+		return float64(n) * dctScale(k, n) * coef[k] / 2
+	}
+	buf[0] = complex(unscaled(0), 0)
+	for k := 1; k < n; k++ {
+		buf[k] = cmplx.Conj(d.twiddle[k]) * complex(unscaled(k), -unscaled(n-k))
+	}
+	d.fft.inverse(buf, scratch)
+	for t := 0; 2*t < n; t++ {
+		x[2*t] = real(buf[t])
+	}
+	for t := 0; 2*t+1 < n; t++ {
+		x[2*t+1] = real(buf[n-1-t])
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/akualab/narray"
+)
+
+func TestDCT(t *testing.T) {
+
+	r := rand.New(rand.NewSource(5))
+	in := narray.Norm[float64](r, 0, 1, 3, 8)
+	out := DCT(nil, in, 1)
+	for i := 0; i < 3; i++ {
+		for k := 0; k < 8; k++ {
+			s := math.Sqrt(2.0 / 8)
+			if k == 0 {
+				s = math.Sqrt(1.0 / 8)
+			}
+			exp := 0.0
+			for j := 0; j < 8; j++ {
+				exp += in.At(i, j) * math.Cos(math.Pi*float64((2*j+1)*k)/16)
+			}
+			if math.Abs(out.At(i, k)-s*exp) > 1e-9 {
+				t.Fatalf("expected %f, got %f", s*exp, out.At(i, k))
+			}
+		}
+	}
+
+	// Orthonormal transforms preserve energy.
+	if e, f := narray.Dot(in, in), narray.Dot(out, out); math.Abs(e-f) > 1e-9 {
+		t.Fatalf("expected energy %f, got %f", e, f)
+	}
+	back := IDCT(nil, out, 1)
+	if !narray.EqualValues(back, in, 1e-9) {
+		t.Fatalf("expected %v, got %v", in, back)
+	}
+
+	// Transform along the first axis.
+	tr := DCT(nil, in.Transpose(), 0)
+	if !narray.EqualValues(tr.Transpose().Copy(), out, 1e-12) {
+		t.Fatalf("expected %v, got %v", out, tr.Transpose())
+	}
+
+	// First coefficients only, and inverse with missing coefficients.
+	first := DCT(narray.New[float64](3, 4), in, 1)
+	if !narray.EqualValues(first, out.Slice(narray.All, narray.Range{Start: 0, Stop: 4, Step: 1}).Copy(), 1e-12) {
+		t.Fatalf("unexpected coefficients %v", first)
+	}
+	smooth := IDCT(narray.New[float64](3, 8), first, 1)
+	padded := out.Copy()
+	for i := 0; i < 3; i++ {
+		for k := 4; k < 8; k++ {
+			padded.Set(0, i, k)
+		}
+	}
+	if !narray.EqualValues(smooth, IDCT(nil, padded, 1), 1e-12) {
+		t.Fatalf("unexpected inverse %v", smooth)
+	}
+	if !panics(func() { DCT(narray.New[float64](3, 9), in, 1) }) {
+		t.Fatalf("expected panic for shape mismatch")
+	}
+}
+
+func TestDCTLong(t *testing.T) {
+
+	// Lengths above maxDCTBasis use the FFT, odd, even and power of two.
+	r := rand.New(rand.NewSource(6))
+	for _, n := range []int{maxDCTBasis + 1, 100, 256} {
+		in := narray.Norm[float64](r, 0, 1, 2, n)
+		out := DCT(nil, in, 1)
+		for i := 0; i < 2; i++ {
+			for _, k := range []int{0, 1, n / 2, n - 1} {
+				s := math.Sqrt(2 / float64(n))
+				if k == 0 {
+					s = math.Sqrt(1 / float64(n))
+				}
+				exp := 0.0
+				for j := 0; j < n; j++ {
+					exp += in.At(i, j) * math.Cos(math.Pi*float64((2*j+1)*k)/float64(2*n))
+				}
+				if math.Abs(out.At(i, k)-s*exp) > 1e-9 {
+					t.Fatalf("length %d: expected %f, got %f", n, s*exp, out.At(i, k))
+				}
+			}
+		}
+		if back := IDCT(nil, out, 1); !narray.EqualValues(back, in, 1e-9) {
+			t.Fatalf("length %d: expected %v, got %v", n, in, back)
+		}
+
+		// Truncated coefficients match the basis computation.
+		first := DCT(narray.New[float64](2, 10), in, 1)
+		smooth := IDCT(narray.New[float64](2, n), first, 1)
+		d := &dctPlan{n: n, basis: make([]float64, n*n, n*n)}
+		for k := 0; k < n; k++ {
+			for j := 0; j < n; j++ {
+				d.basis[k*n+j] = dctScale(k, n) * math.Cos(math.Pi*float64((2*j+1)*k)/float64(2*n))
+			}
+		}
+		x := make([]float64, n, n)
+		for i := 0; i < 2; i++ {
+			d.inverse(x, first.SubArray(i, -1).Copy().Data, nil, nil)
+			if !narray.EqualValues(smooth.SubArray(i, -1), narray.NewArray(x, n), 1e-9) {
+				t.Fatalf("length %d: unexpected inverse %v", n, smooth.SubArray(i, -1))
+			}
+		}
+	}
+	dcts.Lock()
+	defer dcts.Unlock()
+	for n := range dcts.m {
+		if n > maxDCTBasis {
+			t.Fatalf("unexpected cached basis of length %d", n)
+		}
+	}
+}
+
+func TestFilterbank(t *testing.T) {
+
+	const n, bands, rate = 512, 26, 16000.0
+	w := Filterbank[float64](Mel, bands, n, rate, 0, 0)
+	if w.Shape[0] != n/2+1 || w.Shape[1] != bands {
+		t.Fatalf("expected shape [%d %d], got %v", n/2+1, bands, w.Shape)
+	}
+	for b := 0; b < bands; b++ {
+		col := w.SubArray(-1, b)
+		if max := col.Max(); max <= 0.5 || max > 1 {
+			t.Fatalf("band %d: unexpected peak %f", b, max)
+		}
+		if col.Min() < 0 {
+			t.Fatalf("band %d: negative weight", b)
+		}
+	}
+	// Bands are wider at higher frequencies on the mel scale.
+	width := func(b int) int {
+		return narray.Greater(w.SubArray(-1, b), narray.New[float64](1)).Count()
+	}
+	if width(bands-1) <= width(0) {
+		t.Fatalf("expected wider bands at high frequencies, got %d and %d", width(0), width(bands-1))
+	}
+
+	// Linear filters with centers at 1000, 2000 and 3000 Hz.
+	lin := Filterbank[float32](Linear, 3, 16, 8000, 0, 4000)
+	for b := 0; b < 3; b++ {
+		if v, idx := lin.SubArray(-1, b).MaxIdx(); v != 1 || idx[0] != 2*(b+1) {
+			t.Fatalf("band %d: expected peak at bin %d, got %f at %v", b, 2*(b+1), v, idx)
+		}
+	}
+	if math.Abs(MelToHz(HzToMel(1234))-1234) > 1e-9 || math.Abs(HzToMel(1000)-1000) > 0.1 {
+		t.Fatalf("unexpected mel conversion")
+	}
+	if !panics(func() { Filterbank[float64](Mel, bands, n, rate, 0, 9000) }) {
+		t.Fatalf("expected panic for range above the Nyquist frequency")
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package fft computes discrete Fourier and cosine transforms of narrays along an axis.
+
+The transforms are computed in complex128 precision for all element types.
+Plans with the precomputed factors of powers of two and short lengths are
+cached, so repeated transforms of these lengths only pay for the arithmetic.
+
+The spectrum of frames of audio stored as frames x samples is:
+
+	x := na64.New(frames, samples)
+	spec := fft.RFFT[complex128](nil, x, 1) // frames x (samples/2+1)
+	mag := nc128.Abs(nil, spec)
+
+The forward transforms are not scaled and the inverse transforms are
+scaled by 1/n, as in numpy.
+
+Mel frequency cepstral coefficients are a matrix product of the power
+spectrum with the weights of a filterbank followed by a DCT:
+
+	fb := fft.Filterbank[float64](fft.Mel, 26, samples, 16000, 0, 0)
+	logEnergies := na64.Log(nil, na64.MatMul(nil, power, fb)) // frames x 26
+	mfcc := fft.DCT(na64.New(frames, 13), logEnergies, 1)
+*/
+package fft
+
+import (
+	"fmt"
+	"math/cmplx"
+
+	"github.com/akualab/narray"
+)
+
+// FFT computes the discrete Fourier transform of in along axis.
+//
+//	out[..., k, ...] = sum_t in[..., t, ...] * exp(-2*pi*i*k*t/n)
+//
+// If out is nil a new array is created. Use out == in to transform in place.
+// Will panic if the axis is out of range, if the axis has size zero or if
+// the shapes of out and in don't match.
+func FFT[C narray.Complex](out, in *narray.NArray[C], axis int) *narray.NArray[C] {
+	return transform(out, in, axis, "FFT", false)
+}
+
+// IFFT computes the inverse discrete Fourier transform of in along axis.
+//
+//	out[..., t, ...] = 1/n * sum_k in[..., k, ...] * exp(2*pi*i*k*t/n)
+//
+// See FFT for details.
+func IFFT[C narray.Complex](out, in *narray.NArray[C], axis int) *narray.NArray[C] {
+	return transform(out, in, axis, "IFFT", true)
+}
+
+// RFFT computes the discrete Fourier transform of a real narray along axis.
+// The transform of real values is Hermitian symmetric so only the
+// n/2+1 non-negative frequencies are returned, where n is the
+// size of the axis.
+//
+// If out is nil a new array is created.
+// Will panic if the axis is out of range, if the axis has size zero or if
+// the shape of out doesn't match.
+func RFFT[C narray.Complex, F narray.Float](out *narray.NArray[C], in *narray.NArray[F], axis int) *narray.NArray[C] {
+
+	n := axisSize(in, axis)
+	out = outArray(out, in, axis, n/2+1, "RFFT")
+	p := PlanFor(n)
+	buf := make([]complex128, n, n)
+	scratch := p.scratch()
+	si, so := in.Strides[axis], out.Strides[axis]
+	lanes(out, in, axis, func(o, i int) {
+		for k := range buf {
+			buf[k] = complex(float64(in.Data[i+k*si]), 0)
+		}
+		p.forward(buf, scratch)
+		for k := 0; k < n/2+1; k++ {
+			out.Data[o+k*so] = C(buf[k])
+		}
+	})
+	return out
+}
+
+// IRFFT computes the real inverse of RFFT along axis. The result has n
+// values along axis. If n < 1 it is 2*(m-1) where m is the size of the
+// axis of in. Frequencies above n/2 are ignored and missing frequencies
+// are zero. The imaginary parts of the zero and n/2 frequencies are ignored.
+//
+// If out is nil a new array is created.
+// Will panic if the axis is out of range, if the axis has size zero or if
+// the shape of out doesn't match.
+func IRFFT[F narray.Float, C narray.Complex](out *narray.NArray[F], in *narray.NArray[C], axis, n int) *narray.NArray[F] {
+
+	m := axisSize(in, axis)
+	if n < 1 {
+		n = 2 * (m - 1)
+	}
+	if n < 1 {
+		panic(fmt.Sprintf("fft: invalid transform length [%d]", n))
+	}
+	out = outArray(out, in, axis, n, "IRFFT")
+	p := PlanFor(n)
+	buf := make([]complex128, n, n)
+	scratch := p.scratch()
+	si, so := in.Strides[axis], out.Strides[axis]
+	lanes(out, in, axis, func(o, i int) {
+		for k := 0; k <= n/2; k++ {
+			buf[k] = 0
+			if k < m {
+				buf[k] = complex128(in.Data[i+k*si])
+			}
+		}
+		for k := n/2 + 1; k < n; k++ {
+			buf[k] = cmplx.Conj(buf[n-k])
+		}
+		p.inverse(buf, scratch)
+		for k, v := range buf {
+			out.Data[o+k*so] = F(real(v))
+		}
+	})
+	return out
+}
+
+// transform computes the complex transforms along axis.
+func transform[C narray.Complex](out, in *narray.NArray[C], axis int, op string, inverse bool) *narray.NArray[C] {
+
+	n := axisSize(in, axis)
+	out = outArray(out, in, axis, n, op)
+	p := PlanFor(n)
+	buf := make([]complex128, n, n)
+	scratch := p.scratch()
+	si, so := in.Strides[axis], out.Strides[axis]
+	lanes(out, in, axis, func(o, i int) {
+		for k := range buf {
+			buf[k] = complex128(in.Data[i+k*si])
+		}
+		if inverse {
+			p.inverse(buf, scratch)
+		} else {
+			p.forward(buf, scratch)
+		}
+		for k, v := range buf {
+			out.Data[o+k*so] = C(v)
+		}
+	})
+	return out
+}
+
+// axisSize returns the size of axis.
+// Will panic if the axis is out of range or has size zero.
+func axisSize[T narray.Elem](in *narray.NArray[T], axis int) int {
+
+	if axis < 0 || axis >= in.Rank {
+		panic(fmt.Sprintf("fft: axis [%d] out of range for narray of rank [%d]", axis, in.Rank))
+	}
+	if in.Shape[axis] == 0 {
+		panic(fmt.Sprintf("fft: axis [%d] has size zero", axis))
+	}
+	return in.Shape[axis]
+}
+
+// outArray returns out, or a new narray if out is nil, with the shape
+// of in where the size of axis is n.
+// Will panic if the shape of out doesn't match.
+func outArray[D, S narray.Elem](out *narray.NArray[D], in *narray.NArray[S], axis, n int, op string) *narray.NArray[D] {
+
+	shape := make([]int, in.Rank, in.Rank)
+	copy(shape, in.Shape)
+	shape[axis] = n
+	if out == nil {
+		return narray.New[D](shape...)
+	}
+	if !narray.EqualShape(out, &narray.NArray[D]{Shape: shape}) {
+		panic(&narray.ShapeError{Op: op, Msg: "out shape doesn't match transform shape", Shapes: [][]int{out.Shape, shape}})
+	}
+	return out
+}
+
+// lanes calls fn with the offsets in Data of the first element of each
+// vector along axis. The narrays must have the same shape except along axis.
+func lanes[D, S narray.Elem](out *narray.NArray[D], in *narray.NArray[S], axis int, fn func(o, i int)) {
+
+	var axes []int
+	for k := 0; k < in.Rank; k++ {
+		if k != axis {
+			axes = append(axes, k)
+		}
+	}
+	if len(axes) == 0 {
+		fn(out.Offset, in.Offset)
+		return
+	}
+	ot := out.Iter(axes...)
+	it := in.Iter(axes...)
+	for ot.Next() && it.Next() {
+		fn(ot.Offset(), it.Offset())
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/akualab/narray"
+)
+
+func dft(x []complex128) []complex128 {
+	n := len(x)
+	res := make([]complex128, n, n)
+	for k := range res {
+		for t, v := range x {
+			s, c := math.Sincos(-2 * math.Pi * float64(k*t) / float64(n))
+			res[k] += v * complex(c, s)
+		}
+	}
+	return res
+}
+
+func randComplex(r *rand.Rand, n int) []complex128 {
+	x := make([]complex128, n, n)
+	for k := range x {
+		x[k] = complex(r.NormFloat64(), r.NormFloat64())
+	}
+	return x
+}
+
+func checkClose(t *testing.T, name string, got, exp []complex128, tol float64) {
+	for k := range exp {
+		if cmplx.Abs(got[k]-exp[k]) > tol {
+			t.Fatalf("%s: element %d mismatch, expected %v, got %v", name, k, exp[k], got[k])
+		}
+	}
+}
+
+func TestPlan(t *testing.T) {
+
+	r := rand.New(rand.NewSource(7))
+	// Powers of two use radix-2, the other lengths use Bluestein.
+	for _, n := range []int{1, 2, 3, 5, 8, 12, 17, 64, 100, 127} {
+		x := randComplex(r, n)
+		p := NewPlan(n)
+		if p.Len() != n {
+			t.Fatalf("expected length %d, got %d", n, p.Len())
+		}
+		y := make([]complex128, n, n)
+		p.Forward(y, x)
+		checkClose(t, "forward", y, dft(x), 1e-9*float64(n))
+		p.Inverse(y, y)
+		checkClose(t, "inverse", y, x, 1e-9*float64(n))
+	}
+	if PlanFor(12) != PlanFor(12) {
+		t.Fatalf("expected cached plan")
+	}
+	if PlanFor(1024) != PlanFor(1024) {
+		t.Fatalf("expected cached plan")
+	}
+	if PlanFor(1000) == PlanFor(1000) {
+		t.Fatalf("unexpected cached plan of length 1000")
+	}
+	if !panics(func() { NewPlan(0) }) {
+		t.Fatalf("expected panic for zero length")
+	}
+	if !panics(func() { NewPlan(4).Forward(make([]complex128, 4), make([]complex128, 3)) }) {
+		t.Fatalf("expected panic for length mismatch")
+	}
+}
+
+func TestFFTAxis(t *testing.T) {
+
+	r := rand.New(rand.NewSource(11))
+	in := narray.NewArray(randComplex(r, 3*6), 3, 6)
+	for axis := 0; axis < 2; axis++ {
+		out := FFT(nil, in, axis)
+		back := IFFT(nil, out, axis)
+		if !narray.EqualValues(back, in, 1e-9) {
+			t.Fatalf("axis %d: expected %v, got %v", axis, in, back)
+		}
+		// Compare each vector along axis with the DFT.
+		for j := 0; j < in.Shape[1-axis]; j++ {
+			q := []int{j, -1}
+			if axis == 0 {
+				q = []int{-1, j}
+			}
+			x := in.SubArray(q...).Copy().Data
+			y := out.SubArray(q...).Copy().Data
+			checkClose(t, "fft", y, dft(x), 1e-9)
+		}
+	}
+
+	// In place and strided views.
+	exp := FFT(nil, in, 1)
+	x := in.Copy()
+	FFT(x, x, 1)
+	if !narray.EqualValues(x, exp, 1e-12) {
+		t.Fatalf("in place: expected %v, got %v", exp, x)
+	}
+	tr := narray.New[complex128](6, 3)
+	FFT(tr.Transpose(), in, 1)
+	if !narray.EqualValues(tr.Transpose().Copy(), exp, 1e-12) {
+		t.Fatalf("strided: expected %v, got %v", exp, tr.Transpose())
+	}
+
+	// complex64 narrays.
+	in64 := narray.New[complex64](5)
+	in64.Data[1] = 1
+	out64 := FFT(nil, in64, 0)
+	s, c := math.Sincos(-2 * math.Pi * 2 / 5)
+	if cmplx.Abs(complex128(out64.Data[2])-complex(c, s)) > 1e-6 {
+		t.Fatalf("expected %v, got %v", complex(c, s), out64.Data[2])
+	}
+
+	if !panics(func() { FFT(nil, in, 2) }) {
+		t.Fatalf("expected panic for axis out of range")
+	}
+	if !panics(func() { FFT(narray.New[complex128](3, 5), in, 1) }) {
+		t.Fatalf("expected panic for shape mismatch")
+	}
+}
+
+func TestRFFT(t *testing.T) {
+
+	r := rand.New(rand.NewSource(3))
+	for _, n := range []int{1, 2, 7, 8, 10} {
+		in := narray.Norm[float64](r, 0, 1, 4, n)
+		spec := RFFT[complex128](nil, in, 1)
+		if spec.Shape[0] != 4 || spec.Shape[1] != n/2+1 {
+			t.Fatalf("expected shape [4 %d], got %v", n/2+1, spec.Shape)
+		}
+		full := FFT(nil, narray.CComplex[complex128](nil, in, narray.New[float64](4, n)), 1)
+		for i := 0; i < 4; i++ {
+			for k := 0; k < n/2+1; k++ {
+				if cmplx.Abs(spec.At(i, k)-full.At(i, k)) > 1e-9 {
+					t.Fatalf("n=%d: expected %v, got %v", n, full.At(i, k), spec.At(i, k))
+				}
+			}
+		}
+		back := IRFFT[float64](nil, spec, 1, n)
+		if !narray.EqualValues(back, in, 1e-9) {
+			t.Fatalf("n=%d: expected %v, got %v", n, in, back)
+		}
+	}
+
+	// Default output length and float32 narrays.
+	in := narray.NewArray([]float32{1, 2, 3, 4, 5, 6}, 6)
+	spec := RFFT[complex64](nil, in, 0)
+	back := IRFFT[float32](nil, spec, 0, 0)
+	if !narray.EqualValues(back, in, 1e-5) {
+		t.Fatalf("expected %v, got %v", in, back)
+	}
+	if !panics(func() { IRFFT[float32](nil, narray.New[complex64](1), 0, 0) }) {
+		t.Fatalf("expected panic for zero length")
+	}
+}
+
+func panics(fun func()) (b bool) {
+	defer func() {
+		err := recover()
+		if err != nil {
+			b = true
+		}
+	}()
+	fun()
+	return
+}
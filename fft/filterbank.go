@@ -0,0 +1,88 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/akualab/narray"
+)
+
+// Scale is a frequency scale on which the filters of a filterbank are equally spaced.
+type Scale int
+
+const (
+	// Mel is the mel scale, see HzToMel.
+	Mel Scale = iota
+	// Linear is the frequency in Hz.
+	Linear
+)
+
+// HzToMel converts a frequency in Hz to mels.
+//
+//	mel = 2595 * log10(1 + hz/700)
+func HzToMel(hz float64) float64 {
+	return 2595 * math.Log10(1+hz/700)
+}
+
+// MelToHz converts mels to a frequency in Hz. It is the inverse of HzToMel.
+func MelToHz(mel float64) float64 {
+	return 700 * (math.Pow(10, mel/2595) - 1)
+}
+
+// Filterbank returns the weights of a bank of triangular filters for the
+// power spectrum of frames of n samples, as computed by RFFT. The result
+// has shape (n/2+1) x bands, so that the filterbank energies of a power
+// spectrum with shape frames x (n/2+1) are one matrix product.
+//
+// The centers of the filters are equally spaced on the scale between
+// frequencies low and high, in Hz. Filter b starts at the center of filter
+// b-1 and ends at the center of filter b+1, the first filter starts at low
+// and the last one ends at high. The weights are one at the center. If high
+// is zero, it is set to the Nyquist frequency rate/2.
+//
+// Will panic if bands or n are less than one, or unless 0 <= low < high <= rate/2.
+func Filterbank[F narray.Float](scale Scale, bands, n int, rate, low, high float64) *narray.NArray[F] {
+
+	if high == 0 {
+		high = rate / 2
+	}
+	if bands < 1 || n < 1 || low < 0 || low >= high || high > rate/2 {
+		panic(fmt.Sprintf("fft: invalid filterbank with [%d] bands of size [%d] for range [%g, %g] at rate [%g]",
+			bands, n, low, high, rate))
+	}
+	toScale, fromScale := HzToMel, MelToHz
+	if scale == Linear {
+		toScale = func(hz float64) float64 { return hz }
+		fromScale = toScale
+	}
+
+	// Edges of the filters in Hz.
+	edges := make([]float64, bands+2, bands+2)
+	lo, hi := toScale(low), toScale(high)
+	for k := range edges {
+		edges[k] = fromScale(lo + float64(k)*(hi-lo)/float64(bands+1))
+	}
+
+	bins := n/2 + 1
+	w := narray.New[F](bins, bands)
+	for k := 0; k < bins; k++ {
+		hz := float64(k) * rate / float64(n)
+		for b := 0; b < bands; b++ {
+			left, center, right := edges[b], edges[b+1], edges[b+2]
+			var v float64
+			switch {
+			case hz > left && hz <= center:
+				v = (hz - left) / (center - left)
+			case hz > center && hz < right:
+				v = (right - hz) / (right - center)
+			}
+			w.Data[k*bands+b] = F(v)
+		}
+	}
+	return w
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fft
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sync"
+)
+
+// Plan holds the precomputed factors of the transforms of length n.
+// Lengths that are a power of two use a radix-2 transform, other
+// lengths use Bluestein's algorithm on top of a radix-2 transform.
+//
+// A plan is never modified after it is created and is safe for
+// concurrent use. The functions of this package cache the plans
+// of some lengths, see PlanFor.
+type Plan struct {
+	n int
+	// Radix-2 transform of length m, m == n for powers of two.
+	m       int
+	twiddle []complex128
+	perm    []int
+	// Bluestein chirp of length n and the transform of the filter.
+	chirp  []complex128
+	filter []complex128
+}
+
+// NewPlan returns a plan for transforms of length n.
+// Will panic if n < 1.
+func NewPlan(n int) *Plan {
+
+	if n < 1 {
+		panic(fmt.Sprintf("fft: invalid transform length [%d]", n))
+	}
+	m := 1
+	for m < n {
+		m <<= 1
+	}
+	if m != n {
+		// Bluestein's convolution needs a length of at least 2n-1.
+		m = 1
+		for m < 2*n-1 {
+			m <<= 1
+		}
+	}
+	p := &Plan{n: n, m: m}
+
+	p.twiddle = make([]complex128, m/2, m/2)
+	for k := range p.twiddle {
+		s, c := math.Sincos(-2 * math.Pi * float64(k) / float64(m))
+		p.twiddle[k] = complex(c, s)
+	}
+	p.perm = make([]int, m, m)
+	for i, j := 1, 0; i < m; i++ {
+		bit := m >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		p.perm[i] = j
+	}
+
+	if m != n {
+		p.chirp = make([]complex128, n, n)
+		p.filter = make([]complex128, m, m)
+		for k := range p.chirp {
+			// Reduce k*k modulo 2n to keep the angle accurate for large k.
+			kk := (k * k) % (2 * n)
+			s, c := math.Sincos(-math.Pi * float64(kk) / float64(n))
+			p.chirp[k] = complex(c, s)
+			p.filter[k] = cmplx.Conj(p.chirp[k])
+			if k > 0 {
+				p.filter[m-k] = p.filter[k]
+			}
+		}
+		p.radix2(p.filter)
+	}
+	return p
+}
+
+// maxPlanLen is the largest length other than a power of two with a
+// cached plan. Powers of two are always cached, there are at most 63.
+const maxPlanLen = 256
+
+// plans caches the plans created by PlanFor.
+var plans = struct {
+	sync.Mutex
+	m map[int]*Plan
+}{m: map[int]*Plan{}}
+
+// PlanFor returns the plan for transforms of length n.
+// Plans of powers of two and of lengths up to maxPlanLen are created on
+// first use and cached, plans of other lengths are created on every call.
+func PlanFor(n int) *Plan {
+
+	if n > maxPlanLen && n&(n-1) != 0 {
+		return NewPlan(n)
+	}
+	plans.Lock()
+	defer plans.Unlock()
+	p, ok := plans.m[n]
+	if !ok {
+		p = NewPlan(n)
+		plans.m[n] = p
+	}
+	return p
+}
+
+// Len returns the length of the transforms.
+func (p *Plan) Len() int {
+	return p.n
+}
+
+// Forward computes the discrete Fourier transform of src into dst.
+//
+//	dst[k] = sum_t src[t] * exp(-2*pi*i*k*t/n)
+//
+// dst and src may be the same slice.
+// Will panic if the slices don't have length n.
+func (p *Plan) Forward(dst, src []complex128) {
+	p.check(dst, src)
+	copy(dst, src)
+	p.forward(dst, p.scratch())
+}
+
+// Inverse computes the inverse discrete Fourier transform of src into dst.
+//
+//	dst[t] = 1/n * sum_k src[k] * exp(2*pi*i*k*t/n)
+//
+// dst and src may be the same slice.
+// Will panic if the slices don't have length n.
+func (p *Plan) Inverse(dst, src []complex128) {
+	p.check(dst, src)
+	copy(dst, src)
+	p.inverse(dst, p.scratch())
+}
+
+func (p *Plan) check(dst, src []complex128) {
+	if len(dst) != p.n || len(src) != p.n {
+		panic(fmt.Sprintf("fft: slices of length [%d] and [%d] don't match plan of length [%d]", len(dst), len(src), p.n))
+	}
+}
+
+// scratch returns the buffer needed by forward and inverse, nil for radix-2 plans.
+func (p *Plan) scratch() []complex128 {
+	if p.chirp == nil {
+		return nil
+	}
+	return make([]complex128, p.m, p.m)
+}
+
+// forward transforms x in place.
+func (p *Plan) forward(x, scratch []complex128) {
+
+	if p.chirp == nil {
+		p.radix2(x)
+		return
+	}
+
+	// Bluestein: the transform is the convolution of the
+	// chirped input with the conjugate chirp.
+	for k, v := range x {
+		scratch[k] = v * p.chirp[k]
+	}
+	for k := p.n; k < p.m; k++ {
+		scratch[k] = 0
+	}
+	p.radix2(scratch)
+	for k, v := range p.filter {
+		scratch[k] = cmplx.Conj(scratch[k] * v)
+	}
+	// Inverse radix-2 transform using conjugates.
+	p.radix2(scratch)
+	scale := 1 / float64(p.m)
+	for k := range x {
+		x[k] = cmplx.Conj(scratch[k]) * p.chirp[k] * complex(scale, 0)
+	}
+}
+
+// inverse transforms x in place.
+func (p *Plan) inverse(x, scratch []complex128) {
+
+	for k, v := range x {
+		x[k] = cmplx.Conj(v)
+	}
+	p.forward(x, scratch)
+	scale := 1 / float64(p.n)
+	for k, v := range x {
+		x[k] = cmplx.Conj(v) * complex(scale, 0)
+	}
+}
+
+// radix2 computes the transform of length m in place.
+func (p *Plan) radix2(x []complex128) {
+
+	for i, j := range p.perm {
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	m := p.m
+	for size := 2; size <= m; size <<= 1 {
+		half := size >> 1
+		step := m / size
+		for start := 0; start < m; start += size {
+			for k := 0; k < half; k++ {
+				a := x[start+k]
+				b := x[start+k+half] * p.twiddle[k*step]
+				x[start+k] = a + b
+				x[start+k+half] = a - b
+			}
+		}
+	}
+}
@@ -3,7 +3,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:generate go run genarray.go
+//go:build ignore
 
 package main
 
@@ -12,13 +12,18 @@ import (
 	"bytes"
 	"flag"
 	"fmt"
+	"go/ast"
 	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 	"text/template"
 )
@@ -31,6 +36,15 @@ var re1 = regexp.MustCompile("^func ([A-Z][[:alnum:]]*)[(][[:alnum:]]+ float64[)
 // Match lines with pattern: "func Remainder(x, y float64) float64"
 var re2 = regexp.MustCompile("^func ([A-Z][[:alnum:]]*)[(][[:alnum:]]+, [[:alnum:]]+ float64[)] float64")
 
+// Match lines with pattern: "func Exp(x complex128) complex128"
+var re3 = regexp.MustCompile("^func ([A-Z][[:alnum:]]*)[(][[:alnum:]]+ complex128[)] complex128")
+
+// Match lines with pattern: "func Pow(x, y complex128) complex128"
+var re4 = regexp.MustCompile("^func ([A-Z][[:alnum:]]*)[(][[:alnum:]]+, [[:alnum:]]+ complex128[)] complex128")
+
+// Match the type parameter in doc comments.
+var typeParam = regexp.MustCompile(`\bT\b`)
+
 var compare = flag.Bool("compare", false, "Compare generated output to ondisk output. Returnvalue is 0 on match")
 
 type genType struct {
@@ -40,40 +54,67 @@ type genType struct {
 	Float32  bool
 	Biggest  string
 	Smallest string
+	// Complex types have real and imaginary parts of type RealFormat.
+	// Integer types are converted to and from floats of type RealFormat.
+	// Unsigned integer types are also Integer.
+	// Package Real has the narrays of type RealFormat.
+	Complex    bool
+	Integer    bool
+	Unsigned   bool
+	RealFormat string
+	Real       string
 }
 
 var generateTypes = []genType{
 	genType{Format: "float32", Package: "na32", Float32: true, Biggest: "float32(math.MaxFloat32)", Smallest: "float32(-math.MaxFloat32)"},
 	genType{Format: "float64", Package: "na64", Float64: true, Biggest: "math.MaxFloat64", Smallest: "-math.MaxFloat64"},
+	genType{Format: "complex64", Package: "nc64", Complex: true, RealFormat: "float32", Real: "na32"},
+	genType{Format: "complex128", Package: "nc128", Complex: true, RealFormat: "float64", Real: "na64"},
+	genType{Format: "int32", Package: "ni32", Integer: true, RealFormat: "float32", Real: "na32"},
+	genType{Format: "int64", Package: "ni64", Integer: true, RealFormat: "float64", Real: "na64"},
+	genType{Format: "uint8", Package: "nu8", Integer: true, Unsigned: true, RealFormat: "float32", Real: "na32"},
 }
 
 func main() {
 	flag.Parse()
 	ver := runtime.Version()
-	if strings.Contains(ver, "go1.3") || strings.Contains(ver, "go1.2") {
+	if oldGo(ver, "go1.2") || oldGo(ver, "go1.3") {
 		log.Println("Generation requires go v1.4 or later, this is", ver)
 		// We exit with 0, so we don't fail tests.
 		os.Exit(0)
 	}
+	genMath()
 	for _, t := range generateTypes {
 		fmt.Printf("Generating code for format %s in package %s\n", t.Format, t.Package)
-		genMath(t)
+		if err := os.MkdirAll(t.Package, 0755); err != nil {
+			log.Fatalf("creating package directory: %s", err)
+		}
+		genWrappers(t)
 		genFiles(t)
 	}
 }
 
-// Generates math package bindings.
-func genMath(t genType) {
+// oldGo returns true if ver is release rel or one of its point releases.
+// A plain prefix match is not enough because "go1.2" is a prefix of "go1.20".
+func oldGo(ver, rel string) bool {
+	return ver == rel || strings.HasPrefix(ver, rel+".") ||
+		strings.HasPrefix(ver, rel+"rc") || strings.HasPrefix(ver, rel+"beta")
+}
+
+// Generates math package bindings for the generic narray package.
+func genMath() {
 	var g Generator
-	n1, n2 := names()
-	outputName := t.Package + string(os.PathSeparator) + "math_gen.go"
+	n1, n2 := names(re1, re2, exclude)
+	c1, c2 := names(re3, re4, nil)
+	outputName := "math_gen.go"
 	fmt.Printf("Generating %d narray functions:\n1 param:%s\n2 params:%s\n", len(n1)+len(n2), n1, n2)
+	fmt.Printf("Generating %d complex narray functions:\n1 param:%s\n2 params:%s\n", len(c1)+len(c2), c1, c2)
 
 	g.Printf("// generated by narray; DO NOT EDIT\n")
 	g.Printf("// more info at github.com/akualab/narray\n")
 	g.Printf("\n")
-	g.Printf("package %s\n\n", t.Package)
-	g.Printf("import \"math\"\n\n")
+	g.Printf("package narray\n\n")
+	g.Printf("import (\n\"math\"\n\"math/cmplx\"\n)\n\n")
 
 	for _, name := range n1 {
 
@@ -81,15 +122,17 @@ func genMath(t genType) {
 		g.Printf("// See math package in standard lib for details.\n//\n")
 		g.Printf("// If 'out' is nil a new array is created.\n")
 		g.Printf("// Will panic if 'out' and 'in' shapes don't match.\n")
-		g.Printf("func %s(out, in *NArray) *NArray {\n", name)
+		g.Printf("func %s[T Float](out, in *NArray[T]) *NArray[T] {\n", name)
 		g.Printf("	if out == nil {\n")
-		g.Printf("		out = New(in.Shape...)\n")
+		g.Printf("		out = New[T](in.Shape...)\n")
 		g.Printf("	} else if !EqualShape(out, in) {\n")
-		g.Printf("      panic(\"%s:narrays must have equal shape.\")\n", name)
+		g.Printf("      panic(shapeError(\"%s\", \"narrays must have equal shape\", out, in))\n", name)
 		g.Printf("  }\n")
-		g.Printf("	for k,v := range in.Data {\n")
-		g.Printf("		out.Data[k] = %s(math.%s(float64(v)))\n", t.Format, name)
-		g.Printf("	}\n")
+		g.Printf("	unaryOp(func(o, x []T) {\n")
+		g.Printf("		for k,v := range x {\n")
+		g.Printf("			o[k] = T(math.%s(float64(v)))\n", name)
+		g.Printf("		}\n")
+		g.Printf("	}, out, in)\n")
 		g.Printf("	return out\n")
 		g.Printf("}\n")
 		g.Printf("\n")
@@ -100,45 +143,68 @@ func genMath(t genType) {
 		g.Printf("// %s applies math.%s() elementwise to two multidimensional arrays.\n", name, name)
 		g.Printf("// See math package in standard lib for details.\n//\n")
 		g.Printf("// If out is nil a new array is created.\n")
-		g.Printf("// Will panic if 'out', 'a' and 'b' shapes don't match.\n")
-		g.Printf("func %s(out, a, b *NArray) *NArray {\n", name)
+		g.Printf("// Will panic if 'a' and 'b' shapes can't be broadcast or if\n")
+		g.Printf("// the shape of 'out' doesn't match. See BroadcastShapes.\n")
+		g.Printf("func %s[T Float](out, a, b *NArray[T]) *NArray[T] {\n", name)
+		g.Printf("	out = broadcastOut(%q, out, a, b)\n", name)
+		g.Printf("	binaryOp(func(o, x, y []T) {\n")
+		g.Printf("		for k,v := range x {\n")
+		g.Printf("			o[k] = T(math.%s(float64(v), float64(y[k])))\n", name)
+		g.Printf("		}\n")
+		g.Printf("	}, out, a, b)\n")
+		g.Printf("	return out\n")
+		g.Printf("}\n")
+		g.Printf("\n")
+	}
+
+	for _, name := range c1 {
+
+		g.Printf("// C%s applies cmplx.%s() elementwise to a complex multidimensional array.\n", name, name)
+		g.Printf("// See math/cmplx package in standard lib for details.\n//\n")
+		g.Printf("// If 'out' is nil a new array is created.\n")
+		g.Printf("// Will panic if 'out' and 'in' shapes don't match.\n")
+		g.Printf("func C%s[T Complex](out, in *NArray[T]) *NArray[T] {\n", name)
 		g.Printf("	if out == nil {\n")
-		g.Printf("		out = New(a.Shape...)\n")
-		g.Printf("	}\n")
-		g.Printf("  if !EqualShape(out, a, b) {\n")
-		g.Printf("      panic(\"%s:narrays must have equal shape.\")\n", name)
+		g.Printf("		out = New[T](in.Shape...)\n")
+		g.Printf("	} else if !EqualShape(out, in) {\n")
+		g.Printf("      panic(shapeError(\"%s\", \"narrays must have equal shape\", out, in))\n", name)
 		g.Printf("  }\n")
-		g.Printf("	for k,v := range a.Data {\n")
-		g.Printf("		out.Data[k] = %s(math.%s(float64(v), float64(b.Data[k])))\n", t.Format, name)
-		g.Printf("	}\n")
+		g.Printf("	unaryOp(func(o, x []T) {\n")
+		g.Printf("		for k,v := range x {\n")
+		g.Printf("			o[k] = T(cmplx.%s(complex128(v)))\n", name)
+		g.Printf("		}\n")
+		g.Printf("	}, out, in)\n")
 		g.Printf("	return out\n")
 		g.Printf("}\n")
 		g.Printf("\n")
 	}
 
-	// Format the output.
-	src := g.format()
+	for _, name := range c2 {
 
-	// Write to file.
-	if *compare {
-		existing, err := ioutil.ReadFile(outputName)
-		if err != nil {
-			log.Fatalf("opening existing: %s", err)
-		}
-		if bytes.Compare(src, existing) != 0 {
-			log.Println("Mismatch in file", outputName)
-			os.Exit(1)
-		}
-	} else {
-		err := ioutil.WriteFile(outputName, src, 0644)
-		if err != nil {
-			log.Fatalf("writing output: %s", err)
-		}
+		g.Printf("// C%s applies cmplx.%s() elementwise to two complex multidimensional arrays.\n", name, name)
+		g.Printf("// See math/cmplx package in standard lib for details.\n//\n")
+		g.Printf("// If out is nil a new array is created.\n")
+		g.Printf("// Will panic if 'a' and 'b' shapes can't be broadcast or if\n")
+		g.Printf("// the shape of 'out' doesn't match. See BroadcastShapes.\n")
+		g.Printf("func C%s[T Complex](out, a, b *NArray[T]) *NArray[T] {\n", name)
+		g.Printf("	out = broadcastOut(%q, out, a, b)\n", name)
+		g.Printf("	binaryOp(func(o, x, y []T) {\n")
+		g.Printf("		for k,v := range x {\n")
+		g.Printf("			o[k] = T(cmplx.%s(complex128(v), complex128(y[k])))\n", name)
+		g.Printf("		}\n")
+		g.Printf("	}, out, a, b)\n")
+		g.Printf("	return out\n")
+		g.Printf("}\n")
+		g.Printf("\n")
 	}
+
+	g.write(outputName)
 }
 
 // Get list of function names with 1 or 2 parameters
-func names() ([]string, []string) {
+// that match patterns r1 and r2 respectively.
+// Names in exclude are skipped.
+func names(r1, r2 *regexp.Regexp, exclude map[string]bool) ([]string, []string) {
 
 	var names []string
 	var names2 []string
@@ -151,12 +217,12 @@ func names() ([]string, []string) {
 		scanner := bufio.NewScanner(f)
 		for scanner.Scan() {
 			line := scanner.Text()
-			name, ok := f2f(line, re1)
+			name, ok := f2f(line, r1)
 			_, ok2 := exclude[name]
 			if ok && !ok2 {
 				names = append(names, name)
 			}
-			name, ok = f2f(line, re2)
+			name, ok = f2f(line, r2)
 			_, ok2 = exclude[name]
 			if ok && !ok2 {
 				names2 = append(names2, name)
@@ -240,45 +306,478 @@ func (g *Generator) format() []byte {
 	return src
 }
 
-// Generate files from Templates
+// write formats the buffer and writes it to file outputName.
+// With the compare flag, exits if the file on disk is different.
+func (g *Generator) write(outputName string) {
+
+	src := g.format()
+	if *compare {
+		existing, err := ioutil.ReadFile(outputName)
+		if err != nil {
+			log.Fatalf("opening existing: %s", err)
+		}
+		if bytes.Compare(src, existing) != 0 {
+			log.Println("Mismatch in file", outputName)
+			os.Exit(1)
+		}
+		return
+	}
+	err := ioutil.WriteFile(outputName, src, 0644)
+	if err != nil {
+		log.Fatalf("writing output: %s", err)
+	}
+}
+
+// Generate test files from Templates
 // The arrays must match in order
-var outFiles = []string{"gonum.go", "gonum_test.go", "narray.go", "narray_test.go", "arrayfuncs.go"}
-var templateFiles = []string{"gonum.go.tpl", "gonum_test.go.tpl", "narray.go.tpl", "narray_test.go.tpl", "arrayfuncs.go.tpl"}
+var outFiles = []string{"gonum_test.go", "narray_test.go", "reduce_test.go", "slice_test.go",
+	"join_test.go", "mask_test.go", "iter_test.go", "errors_test.go", "matmul_test.go"}
+var templateFiles = []string{"gonum_test.go.tpl", "narray_test.go.tpl", "reduce_test.go.tpl", "slice_test.go.tpl",
+	"join_test.go.tpl", "mask_test.go.tpl", "iter_test.go.tpl", "errors_test.go.tpl", "matmul_test.go.tpl"}
+
+// Test files for the complex packages.
+var complexOutFiles = []string{"complex_test.go"}
+var complexTemplateFiles = []string{"complex_test.go.tpl"}
+
+// Test files for the integer packages.
+var integerOutFiles = []string{"integer_test.go"}
+var integerTemplateFiles = []string{"integer_test.go.tpl"}
 
-// Generate files from templates
+// Generate test files from templates
 func genFiles(t genType) {
-	templ, err := template.ParseFiles(templateFiles...)
+	out, tpl := outFiles, templateFiles
+	switch {
+	case t.Complex:
+		out, tpl = complexOutFiles, complexTemplateFiles
+	case t.Integer:
+		out, tpl = integerOutFiles, integerTemplateFiles
+	}
+	templ, err := template.ParseFiles(tpl...)
 	if err != nil {
 		panic(err)
 	}
-	for i, file := range outFiles {
+	for i, file := range out {
 		fmt.Printf("Generating file %s for type %s\n", file, t.Format)
 
 		var g Generator
 		g.Printf("// generated by narray; DO NOT EDIT\n\n")
-		err = templ.ExecuteTemplate(&g, templateFiles[i], t)
+		err = templ.ExecuteTemplate(&g, tpl[i], t)
 		if err != nil {
 			panic(err)
 		}
-		// Format the output.
-		src := g.format()
+		g.write(t.Package + string(os.PathSeparator) + file)
+	}
+}
 
-		outputName := t.Package + string(os.PathSeparator) + file
-		if *compare {
-			existing, err := ioutil.ReadFile(outputName)
-			if err != nil {
-				log.Fatalf("opening existing: %s", err)
+// Generate the wrappers of the generic narray package.
+//
+// For each source file in the narray package, a file with the same name is
+// written to the type package. Generic types become type aliases instantiated
+// with the element type, and exported functions forward to the generic ones.
+// Methods don't need wrappers, they come with the aliased types.
+//
+// The type constraint of the first type parameter selects the packages:
+// Elem for all packages, Float for the float packages, Complex for the
+// complex packages, Integer for the integer packages and Real for the
+// float and integer packages. In the complex and integer packages, Float
+// type parameters are instantiated with RealFormat.
+func genWrappers(t genType) {
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		log.Fatalf("parsing narray package: %s", err)
+	}
+	pkg, ok := pkgs["narray"]
+	if !ok {
+		log.Fatalf("narray package not found")
+	}
+	var files []string
+	for name := range pkg.Files {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+
+	// Collect generic types and the constraint of their type parameter.
+	generic := map[string]string{}
+	for _, name := range files {
+		for _, decl := range pkg.Files[name].Decls {
+			if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					if ts.TypeParams != nil {
+						generic[ts.Name.Name] = constraint(ts.TypeParams.List[0])
+					}
+				}
 			}
-			if bytes.Compare(src, existing) != 0 {
-				log.Println("Mismatch in file", outputName)
-				os.Exit(1)
+		}
+	}
+
+	for _, name := range files {
+		if only, ok := onlyFiles[name]; ok && !only(t) {
+			continue
+		}
+		w := &wrapper{t: t, generic: generic, file: pkg.Files[name], imports: map[string]bool{}}
+		if !w.gen() {
+			continue
+		}
+		fmt.Printf("Generating wrappers %s for type %s\n", name, t.Format)
+		var g Generator
+		g.Printf("// generated by narray; DO NOT EDIT\n\n")
+		if name == "narray.go" {
+			g.Printf("// Package %s provides multidimensional arrays of type %s.\n", t.Package, t.Format)
+			g.Printf("//\n")
+			g.Printf("// NArray is an alias of narray.NArray[%s] and the functions in this package\n", t.Format)
+			g.Printf("// call the generic functions in package narray. See package narray for details.\n")
+		}
+		g.Printf("package %s\n\n", t.Package)
+		g.Printf("import (\n")
+		var std, local []string
+		for path := range w.imports {
+			if strings.Contains(path, ".") {
+				local = append(local, path)
+			} else {
+				std = append(std, path)
 			}
-		} else {
-			// Write to file.
-			err = ioutil.WriteFile(outputName, src, 0644)
-			if err != nil {
-				log.Fatalf("writing output: %s", err)
+		}
+		local = append(local, "github.com/akualab/narray")
+		sort.Strings(std)
+		sort.Strings(local)
+		for _, path := range std {
+			g.Printf("\t%q\n", path)
+		}
+		if len(std) > 0 {
+			g.Printf("\n")
+		}
+		for _, path := range local {
+			g.Printf("\t%q\n", path)
+		}
+		g.Printf(")\n\n")
+		g.buf.Write(w.buf.Bytes())
+		g.write(t.Package + string(os.PathSeparator) + name)
+	}
+}
+
+// onlyFiles selects the packages that get wrappers for a source file.
+// The other files are wrapped in every package.
+var onlyFiles = map[string]func(t genType) bool{
+	// HTK parameter files hold float32 samples.
+	"htk.go": func(t genType) bool { return !t.Complex && !t.Integer },
+	// The gonum mat interfaces need signed values that convert to float64 and back.
+	"gonum.go": func(t genType) bool { return !t.Complex && !t.Unsigned },
+}
+
+// wrapper generates the declarations that wrap a file of the narray package.
+type wrapper struct {
+	t       genType
+	generic map[string]string
+	file    *ast.File
+	imports map[string]bool
+	buf     bytes.Buffer
+}
+
+// gen writes wrappers for the exported declarations in the file.
+// Returns false if there is nothing exported.
+func (w *wrapper) gen() bool {
+
+	for _, decl := range w.file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			w.genDecl(d)
+		case *ast.FuncDecl:
+			if d.Recv == nil && d.Name.IsExported() {
+				w.funcDecl(d)
 			}
 		}
 	}
+	return w.buf.Len() > 0
+}
+
+// genDecl writes aliases for exported types, constants and variables.
+func (w *wrapper) genDecl(d *ast.GenDecl) {
+
+	for _, spec := range d.Specs {
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			if !s.Name.IsExported() || isConstraint(s) {
+				continue
+			}
+			name := s.Name.Name
+			c, ok := w.generic[name]
+			if ok && !w.accepts(c) {
+				continue
+			}
+			w.doc(d.Doc, s.Doc, len(d.Specs), nil)
+			if ok {
+				fmt.Fprintf(&w.buf, "type %s = narray.%s[%s]\n\n", name, name, w.t.Format)
+			} else {
+				fmt.Fprintf(&w.buf, "type %s = narray.%s\n\n", name, name)
+			}
+		case *ast.ValueSpec:
+			if len(d.Specs) > 1 {
+				continue
+			}
+			for _, n := range s.Names {
+				if !n.IsExported() {
+					continue
+				}
+				w.doc(d.Doc, s.Doc, len(d.Specs), nil)
+				fmt.Fprintf(&w.buf, "%s %s = narray.%s\n\n", d.Tok, n.Name, n.Name)
+			}
+		}
+	}
+	if len(d.Specs) > 1 && (d.Tok == token.CONST || d.Tok == token.VAR) {
+		w.valueGroup(d)
+	}
+}
+
+// valueGroup writes aliases for a group of exported constants or variables
+// as a group with the same comments.
+func (w *wrapper) valueGroup(d *ast.GenDecl) {
+
+	var lines []string
+	for _, spec := range d.Specs {
+		s := spec.(*ast.ValueSpec)
+		for _, n := range s.Names {
+			if !n.IsExported() {
+				continue
+			}
+			if s.Doc != nil {
+				for _, c := range s.Doc.List {
+					lines = append(lines, "\t"+c.Text)
+				}
+			}
+			line := fmt.Sprintf("\t%s = narray.%s", n.Name, n.Name)
+			if s.Comment != nil {
+				// Keep all the comments of the group, a line comment
+				// ends the line.
+				sep := " "
+				for _, c := range s.Comment.List {
+					line += sep + c.Text
+					sep = " "
+					if strings.HasPrefix(c.Text, "//") {
+						sep = "\n\t"
+					}
+				}
+			}
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	w.doc(d.Doc, nil, 1, nil)
+	fmt.Fprintf(&w.buf, "%s (\n%s\n)\n\n", d.Tok, strings.Join(lines, "\n"))
+}
+
+// accepts returns true if the element type of the package satisfies constraint c.
+func (w *wrapper) accepts(c string) bool {
+	switch c {
+	case "Float":
+		return !w.t.Complex && !w.t.Integer
+	case "Real":
+		return !w.t.Complex
+	case "Complex":
+		return w.t.Complex
+	case "Integer":
+		return w.t.Integer
+	}
+	return true
+}
+
+// prefixes are dropped from the names of the functions
+// restricted to a constraint in the packages that accept it.
+var prefixes = map[string]string{"Complex": "C", "Integer": "I"}
+
+// doc writes the doc comment of a declaration.
+// The doc of a group is only used if the group has a single spec.
+// The names in rename are replaced in the text.
+func (w *wrapper) doc(group, spec *ast.CommentGroup, n int, rename map[string]string) {
+	if spec == nil && n == 1 {
+		spec = group
+	}
+	if spec == nil {
+		return
+	}
+	for _, c := range spec.List {
+		text := typeParam.ReplaceAllString(c.Text, w.t.Format)
+		for from, to := range rename {
+			text = regexp.MustCompile(`\b`+from+`\b`).ReplaceAllString(text, to)
+		}
+		fmt.Fprintf(&w.buf, "%s\n", text)
+	}
+}
+
+// funcDecl writes a function that calls the generic function.
+func (w *wrapper) funcDecl(d *ast.FuncDecl) {
+
+	name := d.Name.Name
+	params := map[string]string{}
+	var types []string
+	if tp := d.Type.TypeParams; tp != nil {
+		if !w.accepts(constraint(tp.List[0])) {
+			return
+		}
+		for _, field := range tp.List {
+			typ := w.t.Format
+			if constraint(field) == "Float" && w.t.Real != "" {
+				typ = w.t.RealFormat
+			}
+			for _, n := range field.Names {
+				params[n.Name] = typ
+				types = append(types, typ)
+			}
+		}
+		// Complex functions drop the C prefix in the complex packages
+		// and integer functions drop the I prefix in the integer packages.
+		if p, ok := prefixes[constraint(tp.List[0])]; ok && len(name) > 1 &&
+			strings.HasPrefix(name, p) && strings.ToUpper(name[1:2]) == name[1:2] {
+			name = name[1:]
+		}
+	}
+
+	w.doc(d.Doc, nil, 1, map[string]string{d.Name.Name: name})
+	ft := w.instantiate(d.Type, params).(*ast.FuncType)
+	ft.TypeParams = nil
+
+	var args []string
+	variadic := false
+	for k, field := range ft.Params.List {
+		if len(field.Names) == 0 {
+			field.Names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("p%d", k))}
+		}
+		for _, n := range field.Names {
+			args = append(args, n.Name)
+		}
+		_, variadic = field.Type.(*ast.Ellipsis)
+	}
+	call := "narray." + d.Name.Name
+	if len(types) > 0 {
+		call += "[" + strings.Join(types, ", ") + "]"
+	}
+	call += "(" + strings.Join(args, ", ")
+	if variadic {
+		call += "..."
+	}
+	call += ")"
+	if ft.Results != nil {
+		call = "return " + call
+	}
+	var sig bytes.Buffer
+	printer.Fprint(&sig, token.NewFileSet(), ft)
+	fmt.Fprintf(&w.buf, "func %s%s {\n\t%s\n}\n\n", name, strings.TrimPrefix(sig.String(), "func"), call)
+}
+
+// instantiate returns the expression where the type parameters are
+// replaced with the types in params. Generic types instantiated with the
+// element type are replaced with their aliases, and generic types
+// instantiated with RealFormat use the aliases of package Real.
+// Packages used in the expression are added to the imports.
+func (w *wrapper) instantiate(e ast.Expr, params map[string]string) ast.Expr {
+
+	return rewrite(e, func(n ast.Expr) ast.Expr {
+		switch x := n.(type) {
+		case *ast.Ident:
+			if typ, ok := params[x.Name]; ok {
+				return ast.NewIdent(typ)
+			}
+		case *ast.IndexExpr:
+			id, ok := x.X.(*ast.Ident)
+			if !ok {
+				break
+			}
+			if _, ok := w.generic[id.Name]; !ok {
+				break
+			}
+			if p, ok := x.Index.(*ast.Ident); ok && params[p.Name] == w.t.RealFormat && w.t.Real != "" {
+				w.imports["github.com/akualab/narray/"+w.t.Real] = true
+				return &ast.SelectorExpr{X: ast.NewIdent(w.t.Real), Sel: ast.NewIdent(id.Name)}
+			}
+			return ast.NewIdent(id.Name)
+		case *ast.SelectorExpr:
+			if id, ok := x.X.(*ast.Ident); ok {
+				w.imports[w.importPath(id.Name)] = true
+			}
+		}
+		return n
+	})
+}
+
+// constraint returns the name of the constraint of a type parameter.
+func constraint(field *ast.Field) string {
+	if id, ok := field.Type.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+// importPath returns the import path of package name in the file.
+func (w *wrapper) importPath(name string) string {
+	for _, imp := range w.file.Imports {
+		path := strings.Trim(imp.Path.Value, "\"")
+		if imp.Name != nil && imp.Name.Name == name || imp.Name == nil && filepath.Base(path) == name {
+			return path
+		}
+	}
+	log.Fatalf("import for package %s not found", name)
+	return ""
+}
+
+// isConstraint returns true if the type is an interface used as a type constraint.
+func isConstraint(s *ast.TypeSpec) bool {
+	it, ok := s.Type.(*ast.InterfaceType)
+	if !ok {
+		return false
+	}
+	for _, m := range it.Methods.List {
+		if len(m.Names) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// rewrite replaces the nodes of a type expression with the result of fn.
+// When fn returns a new node, its children are not visited.
+// The expression is modified in place.
+func rewrite(e ast.Expr, fn func(ast.Expr) ast.Expr) ast.Expr {
+
+	if e == nil {
+		return nil
+	}
+	if n := fn(e); n != e {
+		return n
+	}
+	fields := func(fl *ast.FieldList) {
+		if fl == nil {
+			return
+		}
+		for _, f := range fl.List {
+			f.Type = rewrite(f.Type, fn)
+		}
+	}
+	switch x := e.(type) {
+	case *ast.StarExpr:
+		x.X = rewrite(x.X, fn)
+	case *ast.ArrayType:
+		x.Elt = rewrite(x.Elt, fn)
+	case *ast.Ellipsis:
+		x.Elt = rewrite(x.Elt, fn)
+	case *ast.MapType:
+		x.Key = rewrite(x.Key, fn)
+		x.Value = rewrite(x.Value, fn)
+	case *ast.ChanType:
+		x.Value = rewrite(x.Value, fn)
+	case *ast.IndexExpr:
+		x.X = rewrite(x.X, fn)
+		x.Index = rewrite(x.Index, fn)
+	case *ast.FuncType:
+		fields(x.Params)
+		fields(x.Results)
+	case *ast.InterfaceType:
+		fields(x.Methods)
+	}
+	return e
 }
@@ -0,0 +1,442 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import (
+	"math"
+	"unsafe"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Matrix is as an NArray of rank 2 that satisfies the gonum mat.Matrix,
+// mat.Mutable and mat.RawMatrixer interfaces.
+//
+// The gonum interfaces use float64 values. For other element types,
+// values are converted, integers are rounded and clamped to the range of
+// the type, NaN is stored as zero, and complex values use the real part.
+type Matrix[T Elem] NArray[T]
+
+// Vector is as an NArray of rank 1 that satisfies the gonum mat.Vector,
+// mat.MutableVector and mat.RawVectorer interfaces. Values are converted
+// as for Matrix.
+type Vector[T Elem] NArray[T]
+
+var (
+	_ mat.Mutable       = (*Matrix[float64])(nil)
+	_ mat.RawMatrixer   = (*Matrix[float64])(nil)
+	_ mat.MutableVector = (*Vector[float64])(nil)
+	_ mat.RawVectorer   = (*Vector[float64])(nil)
+)
+
+// Matrix creates a subarray of rank 2.
+// Equivalent to SubArray but restricted to the case where the
+// resulting subarray has rank=2. (It will panic otherwise.)
+// The matrix is a view that shares data with the narray.
+// See SubArray for details.
+func (na *NArray[T]) Matrix(query ...int) *Matrix[T] {
+
+	m := na.SubArray(query...)
+	if len(m.Shape) != 2 {
+		panic("matrix must have rank equal two")
+	}
+	return (*Matrix[T])(m)
+}
+
+// Dims returns the dimensions of a Matrix.
+func (m *Matrix[T]) Dims() (r, c int) {
+	na := (*NArray[T])(m)
+	return na.Shape[0], na.Shape[1]
+}
+
+// At returns the value of a matrix element at (r, c). It will panic if r or c are
+// out of bounds for the matrix.
+func (m *Matrix[T]) At(r, c int) float64 {
+	na := (*NArray[T])(m)
+	return float64Of(na.At(r, c))
+}
+
+// Set alters the matrix element at (r, c) to v. It will panic if r or c are out of
+// bounds for the matrix.
+func (m *Matrix[T]) Set(r, c int, v float64) {
+	na := (*NArray[T])(m)
+	na.Set(fromFloat64[T](v), r, c)
+}
+
+// T returns the transpose of the matrix, a *Matrix view with swapped strides.
+// The transpose shares data with the matrix.
+func (m *Matrix[T]) T() mat.Matrix {
+	return (*Matrix[T])((*NArray[T])(m).Transpose())
+}
+
+// RawMatrix returns the matrix as a blas64.General. If the elements are
+// float64 and the columns are contiguous in memory, the result shares data
+// with the matrix, otherwise it holds a copy of the values and changes to
+// the copy are not reflected in the matrix.
+func (m *Matrix[T]) RawMatrix() blas64.General {
+
+	na := (*NArray[T])(m)
+	if g, ok := rawGeneral(na); ok {
+		return g
+	}
+	r, c := m.Dims()
+	g := blas64.General{Rows: r, Cols: c, Stride: c, Data: make([]float64, r*c, r*c)}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			g.Data[i*c+j] = m.At(i, j)
+		}
+	}
+	return g
+}
+
+// String returns matrix as a printable string.
+func (m *Matrix[T]) String() string {
+	na := (*NArray[T])(m)
+	return na.String()
+}
+
+// Vector creates a subarray of rank 1.
+// Equivalent to SubArray but restricted to the case where the
+// resulting subarray has rank=1. (It will panic otherwise.)
+// The vector is a view that shares data with the narray.
+// See SubArray for details.
+//
+// Example, given a 5x10 matrix (rank=2), return the vector
+// of dim 10 for row idx=3:
+//
+//	x := New(5,10)
+//	y := x.Vector(3,-1)
+//	// y = {x_30, x_31, ... , x_39}
+func (na *NArray[T]) Vector(query ...int) *Vector[T] {
+
+	vec := na.SubArray(query...)
+	if len(vec.Shape) != 1 {
+		panic("vector must have rank equal one")
+	}
+	return (*Vector[T])(vec)
+}
+
+// Dims returns the dimensions of the vector as a column matrix.
+func (vec *Vector[T]) Dims() (r, c int) {
+	return vec.Len(), 1
+}
+
+// At returns the value of element (i, j) of the vector as a column matrix.
+// It will panic if i is out of bounds or if j is not zero.
+func (vec *Vector[T]) At(i, j int) float64 {
+	if j != 0 {
+		panic(mat.ErrColAccess)
+	}
+	return vec.AtVec(i)
+}
+
+// AtVec returns the value of element i. It will panic if i is out of bounds.
+func (vec *Vector[T]) AtVec(i int) float64 {
+	na := (*NArray[T])(vec)
+	return float64Of(na.At(i))
+}
+
+// SetVec sets the value of element i to v. It will panic if i is out of bounds.
+func (vec *Vector[T]) SetVec(i int, v float64) {
+	na := (*NArray[T])(vec)
+	na.Set(fromFloat64[T](v), i)
+}
+
+// Len returns the length of the vector.
+func (vec *Vector[T]) Len() int {
+	return vec.Shape[0]
+}
+
+// T returns the transpose of the vector, a row matrix that shares data with the vector.
+func (vec *Vector[T]) T() mat.Matrix {
+	return mat.TransposeVec{Vector: vec}
+}
+
+// RawVector returns the vector as a blas64.Vector. If the elements are
+// float64 and the stride is positive, the result shares data with the
+// vector, otherwise it holds a copy of the values and changes to the
+// copy are not reflected in the vector.
+func (vec *Vector[T]) RawVector() blas64.Vector {
+
+	na := (*NArray[T])(vec)
+	if v, ok := rawVector(na); ok {
+		return v
+	}
+	v := blas64.Vector{N: vec.Len(), Inc: 1, Data: make([]float64, vec.Len(), vec.Len())}
+	for i := range v.Data {
+		v.Data[i] = vec.AtVec(i)
+	}
+	return v
+}
+
+// String returns vector as a printable string.
+func (vec *Vector[T]) String() string {
+	na := (*NArray[T])(vec)
+	return na.String()
+}
+
+// Row returns a slice of T for the row specified. It will panic if the index
+// is out of bounds. If the call requires a copy and dst is not nil it will be used and
+// returned, if it is not nil the number of elements copied will be the minimum of the
+// length of the slice and the number of columns in the matrix.
+func (m *Matrix[T]) Row(dst []T, i int) []T {
+	na := (*NArray[T])(m)
+	_, ncols := m.Dims()
+	if dst == nil {
+		dst = make([]T, ncols, ncols)
+	}
+	for j, _ := range dst {
+		dst[j] = na.At(i, j)
+	}
+	return dst
+}
+
+// Col returns a slice of T for the column specified. It will panic if the index
+// is out of bounds. If the call requires a copy and dst is not nil it will be used and
+// returned, if it is not nil the number of elements copied will be the minimum of the
+// length of the slice and the number of rows in the matrix.
+func (m *Matrix[T]) Col(dst []T, j int) []T {
+	na := (*NArray[T])(m)
+	nrows, _ := m.Dims()
+	if dst == nil {
+		dst = make([]T, nrows, nrows)
+	}
+	for i, _ := range dst {
+		dst[i] = na.At(i, j)
+	}
+	return dst
+}
+
+// SetRow sets the values of the specified row to the values held in a slice of T.
+// It will panic if the index is out of bounds. The number of elements copied is
+// returned and will be the minimum of the length of the slice and the number of columns
+// in the matrix.
+func (m *Matrix[T]) SetRow(i int, src []T) int {
+
+	na := (*NArray[T])(m)
+	numCopied := len(src)
+	if len(src) > m.Shape[1] {
+		numCopied = m.Shape[1]
+	}
+	for j := 0; j < numCopied; j++ {
+		na.Set(src[j], i, j)
+	}
+	return numCopied
+}
+
+// SetCol sets the values of the specified column to the values held in a slice of T.
+// It will panic if the index is out of bounds. The number of elements copied is
+// returned and will be the minimum of the length of the slice and the number of rows
+// in the matrix.
+func (m *Matrix[T]) SetCol(j int, src []T) int {
+
+	na := (*NArray[T])(m)
+	numCopied := len(src)
+	if len(src) > m.Shape[0] {
+		numCopied = m.Shape[0]
+	}
+	for i := 0; i < numCopied; i++ {
+		na.Set(src[i], i, j)
+	}
+	return numCopied
+}
+
+// FromDense returns an narray of rank 2 with the values of d.
+// Float64 narrays share data with d, for other element types
+// the values are converted.
+func FromDense[T Real](d *mat.Dense) *NArray[T] {
+
+	raw := d.RawMatrix()
+	na := &NArray[float64]{
+		Rank:    2,
+		Shape:   []int{raw.Rows, raw.Cols},
+		Strides: []int{raw.Stride, 1},
+		Data:    raw.Data,
+	}
+	return fromFloat64Array[T](na)
+}
+
+// ToDense returns a mat.Dense with the values of the narray of rank 2.
+// The result shares data with float64 narrays whose columns are contiguous
+// in memory, otherwise it holds a copy of the values.
+// Will panic if the rank is not 2 or if the narray is empty.
+func ToDense[T Real](na *NArray[T]) *mat.Dense {
+
+	if na.Rank != 2 || na.Size() == 0 {
+		panic(shapeError("ToDense", "narray must be a non-empty matrix", na))
+	}
+	var d mat.Dense
+	d.SetRawMatrix((*Matrix[T])(na).RawMatrix())
+	return &d
+}
+
+// FromVecDense returns an narray of rank 1 with the values of v.
+// Float64 narrays share data with v, for other element types
+// the values are converted.
+func FromVecDense[T Real](v *mat.VecDense) *NArray[T] {
+
+	raw := v.RawVector()
+	na := &NArray[float64]{
+		Rank:    1,
+		Shape:   []int{raw.N},
+		Strides: []int{raw.Inc},
+		Data:    raw.Data,
+	}
+	return fromFloat64Array[T](na)
+}
+
+// ToVecDense returns a mat.VecDense with the values of the narray of rank 1.
+// The result shares data with float64 narrays with a positive stride,
+// otherwise it holds a copy of the values.
+// Will panic if the rank is not 1 or if the narray is empty.
+func ToVecDense[T Real](na *NArray[T]) *mat.VecDense {
+
+	if na.Rank != 1 || na.Size() == 0 {
+		panic(shapeError("ToVecDense", "narray must be a non-empty vector", na))
+	}
+	var v mat.VecDense
+	v.SetRawVector((*Vector[T])(na).RawVector())
+	return &v
+}
+
+// rawGeneral returns the blas64.General that shares data with the
+// narray of rank 2. Returns false if the layout doesn't allow it.
+func rawGeneral[T Elem](na *NArray[T]) (blas64.General, bool) {
+
+	r, c := na.Shape[0], na.Shape[1]
+	stride := na.Strides[0]
+	if r == 1 {
+		stride = c
+	}
+	if kindOf[T]() != float64Kind || r == 0 || c == 0 || (c > 1 && na.Strides[1] != 1) || stride < c {
+		return blas64.General{}, false
+	}
+	data := f64(na.Data)[na.Offset : na.Offset+(r-1)*stride+c]
+	return blas64.General{Rows: r, Cols: c, Stride: stride, Data: data}, true
+}
+
+// rawVector returns the blas64.Vector that shares data with the
+// narray of rank 1. Returns false if the layout doesn't allow it.
+func rawVector[T Elem](na *NArray[T]) (blas64.Vector, bool) {
+
+	n, inc := na.Shape[0], na.Strides[0]
+	if n == 1 {
+		inc = 1
+	}
+	if kindOf[T]() != float64Kind || n == 0 || inc < 1 {
+		return blas64.Vector{}, false
+	}
+	data := f64(na.Data)[na.Offset : na.Offset+(n-1)*inc+1]
+	return blas64.Vector{N: n, Inc: inc, Data: data}, true
+}
+
+// fromFloat64Array returns na as an narray of type T, sharing data
+// if T is a float64 type.
+func fromFloat64Array[T Elem](na *NArray[float64]) *NArray[T] {
+
+	if kindOf[T]() == float64Kind {
+		return &NArray[T]{
+			Rank:    na.Rank,
+			Shape:   na.Shape,
+			Strides: na.Strides,
+			Data:    unsafe.Slice((*T)(unsafe.Pointer(unsafe.SliceData(na.Data))), len(na.Data)),
+		}
+	}
+	res := New[T](na.Shape...)
+	k := 0
+	it := na.Iter()
+	for it.Next() {
+		res.Data[k] = fromFloat64[T](na.Data[it.Offset()])
+		k++
+	}
+	return res
+}
+
+// float64Of returns v as a float64. Integers are converted and complex
+// values return the real part.
+func float64Of[T Elem](v T) float64 {
+
+	p := unsafe.Pointer(&v)
+	switch kindOf[T]() {
+	case float64Kind:
+		return *(*float64)(p)
+	case float32Kind:
+		return float64(*(*float32)(p))
+	case complex128Kind:
+		return real(*(*complex128)(p))
+	case complex64Kind:
+		return float64(real(*(*complex64)(p)))
+	case int8Kind:
+		return float64(*(*int8)(p))
+	case int16Kind:
+		return float64(*(*int16)(p))
+	case int32Kind:
+		return float64(*(*int32)(p))
+	case int64Kind:
+		return float64(*(*int64)(p))
+	case uint8Kind:
+		return float64(*(*uint8)(p))
+	case uint16Kind:
+		return float64(*(*uint16)(p))
+	case uint32Kind:
+		return float64(*(*uint32)(p))
+	default:
+		return float64(*(*uint64)(p))
+	}
+}
+
+// fromFloat64 returns v as an element of type T. Integers are rounded
+// to the nearest value and clamped to the range of T, NaN is converted
+// to zero. Complex values have a zero imaginary part.
+func fromFloat64[T Elem](v float64) T {
+
+	var res T
+	p := unsafe.Pointer(&res)
+	switch kindOf[T]() {
+	case float64Kind:
+		*(*float64)(p) = v
+	case float32Kind:
+		*(*float32)(p) = float32(v)
+	case complex128Kind:
+		*(*complex128)(p) = complex(v, 0)
+	case complex64Kind:
+		*(*complex64)(p) = complex(float32(v), 0)
+	case int8Kind:
+		*(*int8)(p) = int8(roundClamp(v, math.MinInt8, math.MaxInt8))
+	case int16Kind:
+		*(*int16)(p) = int16(roundClamp(v, math.MinInt16, math.MaxInt16))
+	case int32Kind:
+		*(*int32)(p) = int32(roundClamp(v, math.MinInt32, math.MaxInt32))
+	case int64Kind:
+		*(*int64)(p) = int64(roundClamp(v, math.MinInt64, maxInt64))
+	case uint8Kind:
+		*(*uint8)(p) = uint8(roundClamp(v, 0, math.MaxUint8))
+	case uint16Kind:
+		*(*uint16)(p) = uint16(roundClamp(v, 0, math.MaxUint16))
+	case uint32Kind:
+		*(*uint32)(p) = uint32(roundClamp(v, 0, math.MaxUint32))
+	default:
+		*(*uint64)(p) = uint64(roundClamp(v, 0, maxUint64))
+	}
+	return res
+}
+
+// maxInt64 and maxUint64 are the largest float64 values in the range of
+// int64 and uint64. As float64, MaxInt64 and MaxUint64 round up out of range.
+var (
+	maxInt64  = math.Nextafter(math.MaxInt64, 0)
+	maxUint64 = math.Nextafter(math.MaxUint64, 0)
+)
+
+// roundClamp rounds v to the nearest integer in [lo, hi], NaN is zero.
+func roundClamp(v, lo, hi float64) float64 {
+
+	if math.IsNaN(v) {
+		return 0
+	}
+	return math.Max(lo, math.Min(hi, math.Round(v)))
+}
@@ -0,0 +1,355 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HTKKind is the parameter kind of an HTK file, a base kind in the low six
+// bits and qualifiers in the high bits, for example HTKMFCC|HTKEnergy|HTKDelta,
+// written MFCC_E_D.
+type HTKKind uint16
+
+// Base kinds.
+const (
+	HTKWaveform HTKKind = iota
+	HTKLPC
+	HTKLPRefC
+	HTKLPCepstra
+	HTKLPDelCep
+	HTKIRefC
+	HTKMFCC
+	HTKFBank
+	HTKMelSpec
+	HTKUser
+	HTKDiscrete
+	HTKPLP
+)
+
+// Qualifiers.
+const (
+	HTKEnergy      HTKKind = 0000100 // _E has energy
+	HTKNoAbsEnergy HTKKind = 0000200 // _N absolute energy suppressed
+	HTKDelta       HTKKind = 0000400 // _D has delta coefficients
+	HTKAccel       HTKKind = 0001000 // _A has acceleration coefficients
+	HTKCompressed  HTKKind = 0002000 // _C is compressed
+	HTKZeroMean    HTKKind = 0004000 // _Z has zero mean static coefficients
+	HTKCRC         HTKKind = 0010000 // _K has CRC checksum
+	HTKZeroth      HTKKind = 0020000 // _0 has 0th cepstral coefficient
+	HTKVQ          HTKKind = 0040000 // _V has VQ data
+	HTKThird       HTKKind = 0100000 // _T has third differential coefficients
+)
+
+var htkBaseNames = []string{"WAVEFORM", "LPC", "LPREFC", "LPCEPSTRA", "LPDELCEP",
+	"IREFC", "MFCC", "FBANK", "MELSPEC", "USER", "DISCRETE", "PLP"}
+
+var htkQualifiers = "ENDACZK0VT"
+
+// Base returns the base kind without qualifiers.
+func (k HTKKind) Base() HTKKind {
+	return k & 077
+}
+
+// String returns the HTK name of the kind, such as MFCC_E_D_A.
+func (k HTKKind) String() string {
+
+	name := "ANON"
+	if int(k.Base()) < len(htkBaseNames) {
+		name = htkBaseNames[k.Base()]
+	}
+	for i, q := range htkQualifiers {
+		if k&(HTKEnergy<<uint(i)) != 0 {
+			name += "_" + string(q)
+		}
+	}
+	return name
+}
+
+// ParseHTKKind returns the kind of an HTK name such as MFCC_E_D_A.
+func ParseHTKKind(s string) (HTKKind, error) {
+
+	parts := strings.Split(strings.ToUpper(s), "_")
+	var k HTKKind
+	found := false
+	for i, name := range htkBaseNames {
+		if name == parts[0] {
+			k, found = HTKKind(i), true
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("htk: unknown parameter kind %q", s)
+	}
+	for _, q := range parts[1:] {
+		i := strings.Index(htkQualifiers, q)
+		if len(q) != 1 || i < 0 {
+			return 0, fmt.Errorf("htk: unknown qualifier _%s in %q", q, s)
+		}
+		k |= HTKEnergy << uint(i)
+	}
+	return k, nil
+}
+
+// HTKHeader holds the metadata of an HTK parameter file. The number of
+// samples and the sample size of the file header are the shape of the narray.
+type HTKHeader struct {
+	// SampPeriod is the sample period in units of 100ns, 100000 for a frame every 10ms.
+	SampPeriod int32
+	// Kind is the parameter kind.
+	Kind HTKKind
+}
+
+// ReadHTK reads an HTK parameter file into a frames x dims narray.
+//
+// The file has a 12 byte big-endian header, the number of frames, the sample
+// period, the size of a frame in bytes and the parameter kind, followed by the
+// frames. The values are float32, or int16 for the waveform and discrete kinds.
+// Compressed files, kind _C, store int16 values x and the float32 vectors A and
+// B, the values are (x + B) / A. If the kind is _K, the CRC-16 checksum of the
+// data at the end of the file is verified.
+func ReadHTK[F Float](r io.Reader) (*NArray[F], HTKHeader, error) {
+
+	var h struct {
+		NSamples   int32
+		SampPeriod int32
+		SampSize   int16
+		ParmKind   uint16
+	}
+	if err := binary.Read(r, binary.BigEndian, &h); err != nil {
+		return nil, HTKHeader{}, fmt.Errorf("htk: can't read header: %v", err)
+	}
+	hdr := HTKHeader{SampPeriod: h.SampPeriod, Kind: HTKKind(h.ParmKind)}
+	short := hdr.Kind&HTKCompressed != 0 || hdr.Kind.Base() == HTKWaveform || hdr.Kind.Base() == HTKDiscrete
+	width := 4
+	if short {
+		width = 2
+	}
+	if h.NSamples < 0 || h.SampSize <= 0 || int(h.SampSize)%width != 0 {
+		return nil, hdr, fmt.Errorf("htk: invalid header, [%d] samples of [%d] bytes of kind %s", h.NSamples, h.SampSize, hdr.Kind)
+	}
+	dims, frames := int(h.SampSize)/width, int(h.NSamples)
+	if hdr.Kind&HTKCompressed != 0 {
+		// The vectors A and B take the space of four samples.
+		frames -= 4
+		if frames < 0 {
+			return nil, hdr, fmt.Errorf("htk: invalid header, [%d] samples in compressed file", h.NSamples)
+		}
+	}
+
+	// Read the data before allocating the narray, so that the memory used
+	// is bounded by the size of the file and not by the header.
+	size := int64(h.NSamples) * int64(h.SampSize)
+	data, err := io.ReadAll(io.LimitReader(r, size))
+	if err == nil && int64(len(data)) < size {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, hdr, fmt.Errorf("htk: can't read [%d] samples: %v", h.NSamples, err)
+	}
+	if hdr.Kind&HTKCRC != 0 {
+		var crc uint16
+		if err := binary.Read(r, binary.BigEndian, &crc); err != nil {
+			return nil, hdr, fmt.Errorf("htk: can't read CRC: %v", err)
+		}
+		if c := crc16(data); c != crc {
+			return nil, hdr, fmt.Errorf("htk: CRC mismatch, file has %#04x, data has %#04x", crc, c)
+		}
+	}
+
+	na := New[F](frames, dims)
+	br := bytes.NewReader(data)
+	switch {
+	case hdr.Kind&HTKCompressed != 0:
+		a := make([]float32, dims, dims)
+		b := make([]float32, dims, dims)
+		x := make([]int16, frames*dims, frames*dims)
+		binary.Read(br, binary.BigEndian, a)
+		binary.Read(br, binary.BigEndian, b)
+		binary.Read(br, binary.BigEndian, x)
+		for i, v := range x {
+			j := i % dims
+			na.Data[i] = F((float32(v) + b[j]) / a[j])
+		}
+	case short:
+		x := make([]int16, frames*dims, frames*dims)
+		binary.Read(br, binary.BigEndian, x)
+		for i, v := range x {
+			na.Data[i] = F(v)
+		}
+	default:
+		x := make([]float32, frames*dims, frames*dims)
+		binary.Read(br, binary.BigEndian, x)
+		for i, v := range x {
+			na.Data[i] = F(v)
+		}
+	}
+	return na, hdr, nil
+}
+
+// ReadHTKFile reads an HTK parameter file. See ReadHTK.
+func ReadHTKFile[F Float](fn string) (*NArray[F], HTKHeader, error) {
+
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, HTKHeader{}, err
+	}
+	defer f.Close()
+	return ReadHTK[F](f)
+}
+
+// WriteHTK writes the frames x dims narray x as an HTK parameter file with
+// the metadata in hdr. The file is compressed if hdr.Kind is _C and has a
+// checksum if it is _K, see ReadHTK. The values of the waveform and discrete
+// kinds are rounded to int16, values out of the range of int16 are clamped.
+//
+//	hdr := narray.HTKHeader{SampPeriod: 100000, Kind: narray.HTKMFCC | narray.HTKZeroth}
+//
+// Returns an error if a frame doesn't fit in the header, or if a value is
+// Inf or NaN, or a column is too wide to compress, for the kinds stored as int16.
+// Will panic if x is not a matrix.
+func WriteHTK[F Float](w io.Writer, x *NArray[F], hdr HTKHeader) error {
+
+	if x.Rank != 2 {
+		panic(&ShapeError{Op: "WriteHTK", Msg: "narray must be a frames x dims matrix", Shapes: [][]int{x.Shape}})
+	}
+	frames, dims := x.Shape[0], x.Shape[1]
+	short := hdr.Kind&HTKCompressed != 0 || hdr.Kind.Base() == HTKWaveform || hdr.Kind.Base() == HTKDiscrete
+	width := 4
+	if short {
+		width = 2
+	}
+	nSamples := frames
+	if hdr.Kind&HTKCompressed != 0 {
+		nSamples += 4
+	}
+	if dims == 0 || dims*width > math.MaxInt16 || nSamples > math.MaxInt32 {
+		return fmt.Errorf("htk: can't write [%d] frames of [%d] values", frames, dims)
+	}
+
+	values := x.Copy().Data
+	if short {
+		for i, v := range values {
+			if f := float64(v); math.IsInf(f, 0) || math.IsNaN(f) {
+				return fmt.Errorf("htk: can't write %v at [%d %d] as int16", f, i/dims, i%dims)
+			}
+		}
+	}
+	var data bytes.Buffer
+	switch {
+	case hdr.Kind&HTKCompressed != 0:
+		a, b := htkCompression(values, dims)
+		for j := range a {
+			if a[j] == 0 || math.IsInf(float64(a[j]), 0) || math.IsInf(float64(b[j]), 0) {
+				return fmt.Errorf("htk: can't compress column [%d], the range of values is too large", j)
+			}
+		}
+		binary.Write(&data, binary.BigEndian, a)
+		binary.Write(&data, binary.BigEndian, b)
+		s := make([]int16, len(values), len(values))
+		for i, v := range values {
+			j := i % dims
+			s[i] = htkShort(float64(float32(v)*a[j] - b[j]))
+		}
+		binary.Write(&data, binary.BigEndian, s)
+	case short:
+		s := make([]int16, len(values), len(values))
+		for i, v := range values {
+			s[i] = htkShort(float64(v))
+		}
+		binary.Write(&data, binary.BigEndian, s)
+	default:
+		f := make([]float32, len(values), len(values))
+		for i, v := range values {
+			f[i] = float32(v)
+		}
+		binary.Write(&data, binary.BigEndian, f)
+	}
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, []int32{int32(nSamples), hdr.SampPeriod})
+	binary.Write(&buf, binary.BigEndian, []uint16{uint16(dims * width), uint16(hdr.Kind)})
+	buf.Write(data.Bytes())
+	if hdr.Kind&HTKCRC != 0 {
+		binary.Write(&buf, binary.BigEndian, crc16(data.Bytes()))
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// WriteHTKFile writes an HTK parameter file. See WriteHTK.
+func WriteHTKFile[F Float](fn string, x *NArray[F], hdr HTKHeader) error {
+
+	e := os.MkdirAll(filepath.Dir(fn), 0755)
+	if e != nil {
+		return e
+	}
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+	if err := WriteHTK(f, x, hdr); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// htkCompression returns the scales A and bias B that map the range of
+// each column of the frames x dims values to the range of int16, as in HTK.
+func htkCompression[F Float](values []F, dims int) (a, b []float32) {
+
+	a = make([]float32, dims, dims)
+	b = make([]float32, dims, dims)
+	for j := 0; j < dims; j++ {
+		min, max := math.Inf(1), math.Inf(-1)
+		for i := j; i < len(values); i += dims {
+			v := float64(values[i])
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+		if max <= min {
+			// A constant column, or no frames, is stored as zeros.
+			a[j], b[j] = 1, float32(min)
+			if math.IsInf(min, 0) {
+				b[j] = 0
+			}
+			continue
+		}
+		a[j] = float32(2 * 32767 / (max - min))
+		b[j] = float32((max + min) * 32767 / (max - min))
+	}
+	return a, b
+}
+
+// htkShort rounds v to the nearest int16, values out of range are clamped.
+func htkShort(v float64) int16 {
+	return int16(math.Max(math.MinInt16, math.Min(math.MaxInt16, math.Round(v))))
+}
+
+// crc16 returns the CRC-16 checksum used by HTK, polynomial 0x1021
+// with zero initial value.
+func crc16(data []byte) uint16 {
+
+	var crc uint16
+	for _, c := range data {
+		crc ^= uint16(c) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import "math"
+
+// The functions in this file only apply to integer narrays. Packages
+// ni32, ni64 and nu8 export them without the I prefix. Add, Sub, Mul
+// and Div also work on integer narrays, Div truncates toward zero.
+// As in Go, integer division by zero panics and overflows wrap around.
+
+// IMod returns the remainder of a / b elementwise.
+//
+//	out = a % b
+//
+// The result has the sign of a, as math.Mod.
+// If out is nil a new array is created.
+// Will panic if 'a' and 'b' shapes can't be broadcast or if
+// the shape of 'out' doesn't match. See BroadcastShapes.
+func IMod[T Integer](out, a, b *NArray[T]) *NArray[T] {
+	return intBinary("Mod", out, a, b, func(x, y T) T { return x % y })
+}
+
+// IAnd returns the bitwise and of a and b elementwise.
+//
+//	out = a & b
+//
+// If out is nil a new array is created.
+// Will panic if 'a' and 'b' shapes can't be broadcast or if
+// the shape of 'out' doesn't match. See BroadcastShapes.
+func IAnd[T Integer](out, a, b *NArray[T]) *NArray[T] {
+	return intBinary("And", out, a, b, func(x, y T) T { return x & y })
+}
+
+// IOr returns the bitwise or of a and b elementwise.
+//
+//	out = a | b
+//
+// If out is nil a new array is created.
+// Will panic if 'a' and 'b' shapes can't be broadcast or if
+// the shape of 'out' doesn't match. See BroadcastShapes.
+func IOr[T Integer](out, a, b *NArray[T]) *NArray[T] {
+	return intBinary("Or", out, a, b, func(x, y T) T { return x | y })
+}
+
+// IXor returns the bitwise exclusive or of a and b elementwise.
+//
+//	out = a ^ b
+//
+// If out is nil a new array is created.
+// Will panic if 'a' and 'b' shapes can't be broadcast or if
+// the shape of 'out' doesn't match. See BroadcastShapes.
+func IXor[T Integer](out, a, b *NArray[T]) *NArray[T] {
+	return intBinary("Xor", out, a, b, func(x, y T) T { return x ^ y })
+}
+
+// IAndNot returns the bits of a that are not set in b elementwise.
+//
+//	out = a &^ b
+//
+// If out is nil a new array is created.
+// Will panic if 'a' and 'b' shapes can't be broadcast or if
+// the shape of 'out' doesn't match. See BroadcastShapes.
+func IAndNot[T Integer](out, a, b *NArray[T]) *NArray[T] {
+	return intBinary("AndNot", out, a, b, func(x, y T) T { return x &^ y })
+}
+
+// INot returns the bitwise complement of in elementwise.
+//
+//	out = ^in
+//
+// If out is nil a new array is created.
+// Will panic if 'out' and 'in' shapes don't match.
+func INot[T Integer](out, in *NArray[T]) *NArray[T] {
+	return intUnary(out, in, "Not", func(x T) T { return ^x })
+}
+
+// ILsh shifts the elements of in to the left by n bits.
+//
+//	out = in << n
+//
+// If out is nil a new array is created.
+// Will panic if 'out' and 'in' shapes don't match.
+func ILsh[T Integer](out, in *NArray[T], n uint) *NArray[T] {
+	return intUnary(out, in, "Lsh", func(x T) T { return x << n })
+}
+
+// IRsh shifts the elements of in to the right by n bits.
+// The shift is arithmetic for signed types.
+//
+//	out = in >> n
+//
+// If out is nil a new array is created.
+// Will panic if 'out' and 'in' shapes don't match.
+func IRsh[T Integer](out, in *NArray[T], n uint) *NArray[T] {
+	return intUnary(out, in, "Rsh", func(x T) T { return x >> n })
+}
+
+// IToFloat converts an integer narray to a float narray.
+//
+//	out = float(in)
+//
+// If out is nil a new array is created.
+// Will panic if the shapes of out and in don't match.
+func IToFloat[I Integer, F Float](out *NArray[F], in *NArray[I]) *NArray[F] {
+	return convert(out, in, "ToFloat", func(v I) F { return F(v) })
+}
+
+// IFromFloat converts a float narray to an integer narray.
+// Values are rounded to the nearest integer, halfway away from zero.
+// The result is undefined for NaN and values out of the integer range.
+//
+//	out = int(math.Round(in))
+//
+// If out is nil a new array is created.
+// Will panic if the shapes of out and in don't match.
+func IFromFloat[I Integer, F Float](out *NArray[I], in *NArray[F]) *NArray[I] {
+	return convert(out, in, "FromFloat", func(v F) I { return I(math.Round(float64(v))) })
+}
+
+// intBinary broadcasts a and b and applies fn elementwise.
+func intBinary[T Integer](op string, out, a, b *NArray[T], fn func(x, y T) T) *NArray[T] {
+
+	out = broadcastOut(op, out, a, b)
+	binaryOp(func(o, x, y []T) {
+		for k, v := range x {
+			o[k] = fn(v, y[k])
+		}
+	}, out, a, b)
+	return out
+}
+
+// intUnary applies fn elementwise.
+func intUnary[T Integer](out, in *NArray[T], op string, fn func(x T) T) *NArray[T] {
+
+	if out == nil {
+		out = New[T](in.Shape...)
+	} else if !EqualShape(out, in) {
+		panic(shapeError(op, "narrays must have equal shape", out, in))
+	}
+	unaryOp(func(o, x []T) {
+		for k, v := range x {
+			o[k] = fn(v)
+		}
+	}, out, in)
+	return out
+}
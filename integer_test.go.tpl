@@ -0,0 +1,208 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package {{.Package}}
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akualab/narray/{{.Real}}"
+)
+
+var i23 = NewArray([]{{.Format}}{1, 2, 3, 4, 5, 6}, 2, 3)
+
+func TestArithmetic(t *testing.T) {
+
+	two := NewArray([]{{.Format}}{2}, 1)
+	sum := Add(nil, i23, two)
+	prod := Mul(nil, i23, two)
+	diff := Sub(nil, sum, two)
+	quot := Div(nil, i23, two)
+	mod := Mod(nil, i23, two)
+	for k, v := range i23.Data {
+		if sum.Data[k] != v+2 {
+			t.Fatalf("add: expected %d, got %d", v+2, sum.Data[k])
+		}
+		if prod.Data[k] != v*2 {
+			t.Fatalf("mul: expected %d, got %d", v*2, prod.Data[k])
+		}
+		if diff.Data[k] != v {
+			t.Fatalf("sub: expected %d, got %d", v, diff.Data[k])
+		}
+		if quot.Data[k] != v/2 {
+			t.Fatalf("div: expected %d, got %d", v/2, quot.Data[k])
+		}
+		if mod.Data[k] != v%2 {
+			t.Fatalf("mod: expected %d, got %d", v%2, mod.Data[k])
+		}
+	}
+	if s := i23.Sum(); s != 21 {
+		t.Fatalf("sum: expected 21, got %d", s)
+	}
+	p := {{.Format}}(1)
+	for _, v := range i23.Data {
+		p *= v
+	}
+	if i23.Prod() != p {
+		t.Fatalf("prod: expected %d, got %d", p, i23.Prod())
+	}
+	if !panics(func() { Div(nil, i23, New(1)) }) {
+		t.Fatalf("expected panic for division by zero")
+	}
+}
+
+func TestBitwise(t *testing.T) {
+
+	mask := NewArray([]{{.Format}}{6}, 1)
+	and := And(nil, i23, mask)
+	or := Or(nil, i23, mask)
+	xor := Xor(nil, i23, mask)
+	andNot := AndNot(nil, i23, mask)
+	not := Not(nil, i23)
+	lsh := Lsh(nil, i23, 2)
+	rsh := Rsh(nil, i23, 1)
+	for k, v := range i23.Data {
+		if and.Data[k] != v&6 || or.Data[k] != v|6 || xor.Data[k] != v^6 || andNot.Data[k] != v&^6 {
+			t.Fatalf("expected %d %d %d %d, got %d %d %d %d", v&6, v|6, v^6, v&^6,
+				and.Data[k], or.Data[k], xor.Data[k], andNot.Data[k])
+		}
+		if not.Data[k] != ^v {
+			t.Fatalf("not: expected %d, got %d", ^v, not.Data[k])
+		}
+		if lsh.Data[k] != v<<2 || rsh.Data[k] != v>>1 {
+			t.Fatalf("shift: expected %d %d, got %d %d", v<<2, v>>1, lsh.Data[k], rsh.Data[k])
+		}
+	}
+	if !panics(func() { Not(New(3), i23) }) {
+		t.Fatalf("expected panic for shape mismatch")
+	}
+}
+
+func TestOrder(t *testing.T) {
+
+	na := NewArray([]{{.Format}}{3, 9, 0, 7, 9, 1}, 2, 3)
+	if v, idx := na.MaxIdx(); v != 9 || idx[0] != 0 || idx[1] != 1 {
+		t.Fatalf("expected max 9 at [0 1], got %d at %v", v, idx)
+	}
+	if v, idx := na.MinIdx(); v != 0 || idx[0] != 0 || idx[1] != 2 {
+		t.Fatalf("expected min 0 at [0 2], got %d at %v", v, idx)
+	}
+	if na.Max() != 9 || na.Min() != 0 {
+		t.Fatalf("expected max 9 and min 0, got %d and %d", na.Max(), na.Min())
+	}
+	max := MaxArray(nil, na, i23)
+	min := MinArray(nil, na, i23)
+	if !EqualValues(max, NewArray([]{{.Format}}{3, 9, 3, 7, 9, 6}, 2, 3), 0) {
+		t.Fatalf("unexpected max array %v", max)
+	}
+	if !EqualValues(min, NewArray([]{{.Format}}{1, 2, 0, 4, 5, 1}, 2, 3), 0) {
+		t.Fatalf("unexpected min array %v", min)
+	}
+	if m := Greater(na, i23); m.Count() != 4 || !m.At(0, 0) || m.At(0, 2) {
+		t.Fatalf("unexpected mask %v", m.Data)
+	}
+
+	// Count table with the number of labels per row.
+	counts := SumAxis(nil, FromMask(Greater(na, NewArray([]{{.Format}}{2}, 1))), false, 1)
+	if counts.At(0) != 2 || counts.At(1) != 2 {
+		t.Fatalf("expected counts [2 2], got %v", counts.Data)
+	}
+	best := ArgMaxAxis(na, 1)
+	if best[0] != 1 || best[1] != 1 {
+		t.Fatalf("expected best [1 1], got %v", best)
+	}
+}
+
+func TestMatMul(t *testing.T) {
+
+	// 2x3 times 3x2.
+	b := NewArray([]{{.Format}}{1, 0, 0, 1, 1, 1}, 3, 2)
+	exp := NewArray([]{{.Format}}{4, 5, 10, 11}, 2, 2)
+	if out := MatMul(nil, i23, b); !EqualValues(out, exp, 0) {
+		t.Fatalf("expected %v, got %v", exp.Data, out.Data)
+	}
+	v := NewArray([]{{.Format}}{1, 1, 1}, 3)
+	if out := MatMul(nil, i23, v); out.At(0) != 6 || out.At(1) != 15 {
+		t.Fatalf("expected [6 15], got %v", out.Data)
+	}
+}
+
+func TestConvert(t *testing.T) {
+
+	f := ToFloat(nil, i23)
+	for k, v := range i23.Data {
+		if f.Data[k] != {{.RealFormat}}(v) {
+			t.Fatalf("expected %f, got %f", {{.RealFormat}}(v), f.Data[k])
+		}
+	}
+	labels := {{.Real}}.NewArray([]{{.RealFormat}}{0.9999, 2, 2.5, 3.4}, 4)
+	res := FromFloat(nil, labels)
+	if !EqualValues(res, NewArray([]{{.Format}}{1, 2, 3, 3}, 4), 0) {
+		t.Fatalf("expected [1 2 3 3], got %v", res.Data)
+	}
+
+	// Strided output.
+	out := New(3, 2)
+	FromFloat(out.Transpose(), ToFloat(nil, i23))
+	if out.At(2, 1) != 6 || out.At(0, 1) != 4 {
+		t.Fatalf("expected transposed values, got %v", out)
+	}
+	if !panics(func() { ToFloat({{.Real}}.New(3), i23) }) {
+		t.Fatalf("expected panic for shape mismatch")
+	}
+
+	m := ToMask(NewArray([]{{.Format}}{0, 3, 0, 1}, 2, 2))
+	if m.Count() != 2 || !m.At(0, 1) || m.At(1, 0) {
+		t.Fatalf("unexpected mask %v", m.Data)
+	}
+	if na := FromMask(m); !EqualValues(na, NewArray([]{{.Format}}{0, 1, 0, 1}, 2, 2), 0) {
+		t.Fatalf("expected [0 1 0 1], got %v", na.Data)
+	}
+}
+
+func TestSubArray(t *testing.T) {
+
+	na := New(2, 3, 4)
+	for k := range na.Data {
+		na.Data[k] = {{.Format}}(k)
+	}
+	sub := na.SubArray(-1, 1, -1)
+	if sub.At(1, 2) != na.At(1, 1, 2) {
+		t.Fatalf("expected %d, got %d", na.At(1, 1, 2), sub.At(1, 2))
+	}
+	if s := sub.Sprint(func(na *NArray, k int) bool { return k == 0 }); !strings.Contains(s, "=> 4\n") {
+		t.Fatalf("expected integer value in %q", s)
+	}
+}
+
+func TestJSON(t *testing.T) {
+
+	s, err := i23.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(s, "[1,2,3,4,5,6]") {
+		t.Fatalf("expected data as numbers, got %s", s)
+	}
+	var res NArray
+	if err := res.UnmarshalJSON([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if !EqualValues(&res, i23, 0) {
+		t.Fatalf("expected %v, got %v", i23, &res)
+	}
+}
+
+func panics(fun func()) (b bool) {
+	defer func() {
+		err := recover()
+		if err != nil {
+			b = true
+		}
+	}()
+	fun()
+	return
+}
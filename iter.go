@@ -0,0 +1,128 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import "fmt"
+
+// Iterator walks the elements of an narray in row-major order.
+// Indices are updated incrementally, iterating does not allocate.
+//
+//	it := x.Iter()
+//	for it.Next() {
+//	    fmt.Println(it.Index(), it.Value())
+//	}
+//
+// Iterator works on views, Offset is the position of the current
+// element in the Data slice of the narray.
+type Iterator[T Elem] struct {
+	na      *NArray[T]
+	axes    []int
+	index   []int
+	offset  int
+	started bool
+	done    bool
+}
+
+// Iter returns an iterator over the narray.
+//
+// If axes are given, only those axes are iterated in the order given and the
+// index of the other axes stays at zero. For example, to iterate over the rows
+// of a matrix x and access each row as a contiguous segment of Data:
+//
+//	it := x.Iter(0)
+//	for it.Next() {
+//	    row := x.Data[it.Offset() : it.Offset()+x.Shape[1]]
+//	}
+//
+// Will panic if an axis is out of range or repeated.
+func (na *NArray[T]) Iter(axes ...int) *Iterator[T] {
+
+	if len(axes) == 0 {
+		axes = make([]int, na.Rank, na.Rank)
+		for k := range axes {
+			axes[k] = k
+		}
+	} else {
+		seen := make([]bool, na.Rank, na.Rank)
+		for _, a := range axes {
+			if a < 0 || a >= na.Rank || seen[a] {
+				panic(shapeError("Iter", fmt.Sprintf("invalid axes %v", axes), na))
+			}
+			seen[a] = true
+		}
+	}
+	return &Iterator[T]{
+		na:     na,
+		axes:   axes,
+		index:  make([]int, na.Rank, na.Rank),
+		offset: na.Offset,
+	}
+}
+
+// Next advances the iterator to the next element. It must be called
+// before accessing the first element. Returns false when there are no
+// more elements.
+func (it *Iterator[T]) Next() bool {
+
+	if it.done {
+		return false
+	}
+	na := it.na
+	if !it.started {
+		it.started = true
+		for _, a := range it.axes {
+			if na.Shape[a] == 0 {
+				it.done = true
+				return false
+			}
+		}
+		return true
+	}
+	for k := len(it.axes) - 1; k >= 0; k-- {
+		a := it.axes[k]
+		it.index[a]++
+		it.offset += na.Strides[a]
+		if it.index[a] < na.Shape[a] {
+			return true
+		}
+		it.offset -= it.index[a] * na.Strides[a]
+		it.index[a] = 0
+	}
+	it.done = true
+	return false
+}
+
+// Index returns the indices of the current element. The slice is
+// reused by the iterator and must not be modified.
+func (it *Iterator[T]) Index() []int {
+	return it.index
+}
+
+// Offset returns the position of the current element in the Data slice.
+func (it *Iterator[T]) Offset() int {
+	return it.offset
+}
+
+// Value returns the value of the current element.
+func (it *Iterator[T]) Value() T {
+	return it.na.Data[it.offset]
+}
+
+// SetValue sets the value of the current element.
+func (it *Iterator[T]) SetValue(v T) {
+	it.na.Data[it.offset] = v
+}
+
+// Reset restarts the iteration from the first element.
+func (it *Iterator[T]) Reset() {
+
+	for k := range it.index {
+		it.index[k] = 0
+	}
+	it.offset = it.na.Offset
+	it.started = false
+	it.done = false
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package {{.Package}}
+
+import "testing"
+
+func TestIter(t *testing.T) {
+
+	it := na234.Iter()
+	k := 0
+	for it.Next() {
+		idx := it.Index()
+		rev := na234.ReverseIndex(k)
+		for i := range idx {
+			if idx[i] != rev[i] {
+				t.Fatalf("expected index %v, got %v", rev, idx)
+			}
+		}
+		if it.Value() != na234.At(idx...) || it.Offset() != na234.Index(idx...) {
+			t.Fatalf("expected %f, got %f", na234.At(idx...), it.Value())
+		}
+		k++
+	}
+	if k != 24 {
+		t.Fatalf("expected 24 elements, got %d", k)
+	}
+	if it.Next() {
+		t.Fatalf("expected end of iteration")
+	}
+
+	// Strided view.
+	tr := na234.Permute(2, 1, 0).Slice(Range{Start: 1, Stop: End, Step: 2})
+	it = tr.Iter()
+	for it.Next() {
+		if it.Value() != tr.At(it.Index()...) {
+			t.Fatalf("expected %f, got %f", tr.At(it.Index()...), it.Value())
+		}
+	}
+
+	// Empty narray.
+	if New(3, 0).Iter().Next() {
+		t.Fatalf("expected no elements")
+	}
+
+	// Scalar.
+	it = New().Iter()
+	if !it.Next() || it.Next() {
+		t.Fatalf("expected one element")
+	}
+}
+
+func TestIterAxes(t *testing.T) {
+
+	na := na234.Copy()
+	it := na.Iter(1, 0)
+	var got [][]int
+	for it.Next() {
+		got = append(got, []int{it.Index()[0], it.Index()[1], it.Index()[2]})
+		it.SetValue(-1)
+	}
+	if len(got) != 6 || got[1][0] != 1 || got[1][1] != 0 || got[2][1] != 1 || got[5][2] != 0 {
+		t.Fatalf("unexpected iteration order %v", got)
+	}
+	if na.SubArray(-1, -1, 0).Sum() != -6 || na.At(1, 1, 1) != na234.At(1, 1, 1) {
+		t.Fatalf("unexpected values %v", na)
+	}
+
+	it.Reset()
+	n := 0
+	for it.Next() {
+		n++
+	}
+	if n != 6 {
+		t.Fatalf("expected 6 elements after reset, got %d", n)
+	}
+}
+
+func TestIterAllocs(t *testing.T) {
+
+	it := randna[0].Iter()
+	allocs := testing.AllocsPerRun(10, func() {
+		it.Reset()
+		for it.Next() {
+			_ = it.Index()
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations, got %f", allocs)
+	}
+}
+
+func BenchmarkIter(b *testing.B) {
+
+	na := randna[0].Permute(3, 2, 1, 0)
+	it := na.Iter()
+	for i := 0; i < b.N; i++ {
+		it.Reset()
+		for it.Next() {
+		}
+	}
+}
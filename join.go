@@ -0,0 +1,152 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import "fmt"
+
+// Concatenate joins narrays along an existing axis.
+// All the narrays must have the same rank and the same shape except along axis.
+//
+//	a := New(100, 13)
+//	b := New(50, 13)
+//	c := Concatenate(0, a, b) // c has shape 150x13
+//
+// Will panic if there are no input narrays or if the shapes don't match.
+func Concatenate[T Elem](axis int, in ...*NArray[T]) *NArray[T] {
+
+	if len(in) == 0 {
+		panic(&ShapeError{Op: "Concatenate", Msg: "no input narrays"})
+	}
+	rank := in[0].Rank
+	if axis < 0 || axis >= rank {
+		panic(shapeError("Concatenate", fmt.Sprintf("axis [%d] out of range", axis), in[0]))
+	}
+	shape := make([]int, rank, rank)
+	copy(shape, in[0].Shape)
+	shape[axis] = 0
+	for _, na := range in {
+		if na.Rank != rank {
+			panic(shapeError("Concatenate", "narrays must have equal rank", in[0], na))
+		}
+		for k, v := range na.Shape {
+			if k != axis && v != shape[k] {
+				panic(shapeError("Concatenate", fmt.Sprintf("shapes don't match outside axis [%d]", axis), in[0], na))
+			}
+		}
+		shape[axis] += na.Shape[axis]
+	}
+
+	// Each input is copied as a sequence of contiguous blocks, one block
+	// for each combination of indices of the axes before axis.
+	out := New[T](shape...)
+	outer := blockSize(shape[:axis])
+	n := blockSize(shape[axis:])
+	pos := 0
+	for _, na := range in {
+		src := na.Contiguous().data()
+		m := blockSize(na.Shape[axis:])
+		for o := 0; o < outer; o++ {
+			copy(out.Data[o*n+pos:o*n+pos+m], src[o*m:(o+1)*m])
+		}
+		pos += m
+	}
+	return out
+}
+
+// blockSize returns the number of elements in an narray of the given shape.
+func blockSize(shape []int) int {
+	size := 1
+	for _, v := range shape {
+		size *= v
+	}
+	return size
+}
+
+// Stack joins narrays of equal shape along a new axis.
+//
+//	a := New(13)
+//	b := New(13)
+//	c := Stack(0, a, b) // c has shape 2x13
+//	d := Stack(1, a, b) // d has shape 13x2
+//
+// Will panic if there are no input narrays or if the shapes don't match.
+func Stack[T Elem](axis int, in ...*NArray[T]) *NArray[T] {
+
+	if len(in) == 0 {
+		panic(&ShapeError{Op: "Stack", Msg: "no input narrays"})
+	}
+	if axis < 0 || axis > in[0].Rank {
+		panic(shapeError("Stack", fmt.Sprintf("axis [%d] out of range", axis), in[0]))
+	}
+	if !EqualShape(in[0], in...) {
+		panic(shapeError("Stack", "narrays must have equal shape", in...))
+	}
+	specs := make([]SliceSpec, 0, axis+1)
+	for k := 0; k < axis; k++ {
+		specs = append(specs, All)
+	}
+	specs = append(specs, NewAxis)
+	views := make([]*NArray[T], len(in), len(in))
+	for k, na := range in {
+		views[k] = na.Slice(specs...)
+	}
+	return Concatenate(axis, views...)
+}
+
+// Split divides an narray into equal sections along an axis.
+// The sections are views that share data with the narray.
+//
+//	x := New(100, 13)
+//	s := Split(x, 0, 4) // four narrays of shape 25x13
+//
+// Will panic if the size of the axis is not a multiple of sections.
+func Split[T Elem](na *NArray[T], axis int, sections int) []*NArray[T] {
+
+	if axis < 0 || axis >= na.Rank {
+		panic(shapeError("Split", fmt.Sprintf("axis [%d] out of range", axis), na))
+	}
+	if sections <= 0 || na.Shape[axis]%sections != 0 {
+		panic(shapeError("Split", fmt.Sprintf("axis [%d] can't be divided in [%d] equal sections", axis, sections), na))
+	}
+	n := na.Shape[axis] / sections
+	indices := make([]int, sections-1, sections-1)
+	for k := range indices {
+		indices[k] = (k + 1) * n
+	}
+	return SplitAt(na, axis, indices...)
+}
+
+// SplitAt divides an narray along an axis at the given indices.
+// The indices must be increasing, the result has len(indices)+1 sections.
+// The sections are views that share data with the narray.
+//
+//	x := New(100, 13)
+//	s := SplitAt(x, 0, 20, 50) // shapes 20x13, 30x13 and 50x13
+//
+// Will panic if the indices are out of range or not increasing.
+func SplitAt[T Elem](na *NArray[T], axis int, indices ...int) []*NArray[T] {
+
+	if axis < 0 || axis >= na.Rank {
+		panic(shapeError("SplitAt", fmt.Sprintf("axis [%d] out of range", axis), na))
+	}
+	specs := make([]SliceSpec, axis+1, axis+1)
+	for k := 0; k < axis; k++ {
+		specs[k] = All
+	}
+	res := make([]*NArray[T], 0, len(indices)+1)
+	start := 0
+	stops := make([]int, len(indices), len(indices)+1)
+	copy(stops, indices)
+	for _, stop := range append(stops, na.Shape[axis]) {
+		if stop < start || stop > na.Shape[axis] {
+			panic(shapeError("SplitAt", fmt.Sprintf("invalid indices %v for axis [%d]", indices, axis), na))
+		}
+		specs[axis] = Range{start, stop, 1}
+		res = append(res, na.Slice(specs...))
+		start = stop
+	}
+	return res
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package {{.Package}}
+
+import "testing"
+
+func TestConcatenate(t *testing.T) {
+
+	for axis := 0; axis < 3; axis++ {
+		parts := SplitAt(na234, axis, 1)
+		c := Concatenate(axis, parts...)
+		if !EqualValues(c, na234, 0) {
+			t.Fatalf("axis %d: expected same values", axis)
+		}
+	}
+
+	// Non-contiguous inputs.
+	c := Concatenate(1, x.Transpose(), x.Transpose())
+	if c.Shape[0] != 5 || c.Shape[1] != 6 {
+		t.Fatalf("expected shape [5 6], got %v", c.Shape)
+	}
+	if c.At(4, 1) != x.At(1, 4) || c.At(4, 5) != x.At(2, 4) {
+		t.Fatalf("concatenate of views failed: %v", c)
+	}
+
+	if !panics(func() { Concatenate(0, x, New(3, 4)) }) {
+		t.Errorf("did not panic with shape mismatch")
+	}
+	if !panics(func() { Concatenate(0, x, na234) }) {
+		t.Errorf("did not panic with rank mismatch")
+	}
+}
+
+func TestStack(t *testing.T) {
+
+	s := Stack(0, x, y)
+	if s.Rank != 3 || s.Shape[0] != 2 || s.Shape[1] != 3 || s.Shape[2] != 5 {
+		t.Fatalf("expected shape [2 3 5], got %v", s.Shape)
+	}
+	if !EqualValues(s.SubArray(1, -1, -1), y, 0) {
+		t.Fatalf("expected same values")
+	}
+	s = Stack(2, x, y)
+	if s.Shape[0] != 3 || s.Shape[1] != 5 || s.Shape[2] != 2 {
+		t.Fatalf("expected shape [3 5 2], got %v", s.Shape)
+	}
+	if s.At(2, 3, 0) != x.At(2, 3) || s.At(2, 3, 1) != y.At(2, 3) {
+		t.Fatalf("stack failed: %v", s)
+	}
+	if !panics(func() { Stack(0, x, New(3)) }) {
+		t.Errorf("did not panic with shape mismatch")
+	}
+}
+
+func TestSplit(t *testing.T) {
+
+	parts := Split(x, 1, 5)
+	if len(parts) != 5 {
+		t.Fatalf("expected 5 sections, got %d", len(parts))
+	}
+	for j, p := range parts {
+		if p.Shape[0] != 3 || p.Shape[1] != 1 {
+			t.Fatalf("expected shape [3 1], got %v", p.Shape)
+		}
+		if p.At(2, 0) != x.At(2, j) {
+			t.Fatalf("expected %f, got %f", x.At(2, j), p.At(2, 0))
+		}
+	}
+
+	parts = SplitAt(x, 1, 1, 1, 4)
+	if len(parts) != 4 || parts[1].Shape[1] != 0 || parts[2].Shape[1] != 3 || parts[3].Shape[1] != 1 {
+		t.Fatalf("unexpected sections %v", parts)
+	}
+
+	if !panics(func() { Split(x, 1, 2) }) {
+		t.Errorf("did not panic with unequal sections")
+	}
+	if !panics(func() { SplitAt(x, 1, 3, 2) }) {
+		t.Errorf("did not panic with decreasing indices")
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kaldi
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/akualab/narray"
+)
+
+// Reader iterates over the entries of an archive in the order they
+// are stored:
+//
+//	r := kaldi.NewReader[float64](f)
+//	for r.Next() {
+//	    key, x := r.Key(), r.Value()
+//	}
+//	err := r.Err()
+type Reader[F narray.Float] struct {
+	br     *bufio.Reader
+	cr     *countReader
+	key    string
+	value  *narray.NArray[F]
+	offset int64
+	err    error
+}
+
+// NewReader returns a reader of the archive in r.
+func NewReader[F narray.Float](r io.Reader) *Reader[F] {
+
+	cr := &countReader{r: r}
+	return &Reader[F]{br: bufio.NewReader(cr), cr: cr}
+}
+
+// Next reads the next entry. Returns false at the end of the archive
+// or after an error, see Err.
+func (r *Reader[F]) Next() bool {
+
+	if r.err != nil {
+		return false
+	}
+	r.key, r.value = "", nil
+	if err := skipSpace(r.br, false); err != nil {
+		if err != io.EOF {
+			r.err = err
+		}
+		return false
+	}
+	key, err := r.br.ReadString(' ')
+	if err != nil || strings.ContainsAny(key, "\t\n\r") {
+		r.err = fmt.Errorf("kaldi: invalid key %q", strings.TrimSpace(key))
+		return false
+	}
+	r.offset = r.cr.n - int64(r.br.Buffered())
+	r.value, r.err = readObject[F](r.br)
+	if r.err != nil {
+		r.err = fmt.Errorf("%w, key %q", r.err, key[:len(key)-1])
+		return false
+	}
+	r.key = key[:len(key)-1]
+	return true
+}
+
+// Key returns the key of the current entry.
+func (r *Reader[F]) Key() string {
+	return r.key
+}
+
+// Value returns the matrix or the vector of the current entry.
+func (r *Reader[F]) Value() *narray.NArray[F] {
+	return r.value
+}
+
+// Offset returns the position of the object of the current entry in
+// the archive, the offset of the entry in an scp file.
+func (r *Reader[F]) Offset() int64 {
+	return r.offset
+}
+
+// Err returns the first error found by Next.
+func (r *Reader[F]) Err() error {
+	return r.err
+}
+
+// ReadAll returns all the entries of the archive in r.
+func ReadAll[F narray.Float](r io.Reader) (keys []string, values []*narray.NArray[F], err error) {
+
+	ar := NewReader[F](r)
+	for ar.Next() {
+		keys = append(keys, ar.Key())
+		values = append(values, ar.Value())
+	}
+	return keys, values, ar.Err()
+}
+
+// Writer writes entries to an archive.
+type Writer[F narray.Float] struct {
+	cw     *countWriter
+	binary bool
+}
+
+// NewWriter returns a writer of entries in binary form, or in text form
+// if binary is false.
+func NewWriter[F narray.Float](w io.Writer, binary bool) *Writer[F] {
+	return &Writer[F]{cw: &countWriter{w: w}, binary: binary}
+}
+
+// Write writes the matrix or the vector na with key. The offset is the
+// position of the object in the archive, as needed by scp files:
+//
+//	off, err := w.Write("utt1", feats)
+//	scp = append(scp, kaldi.ScpEntry{Key: "utt1", Path: "feats.ark", Offset: off})
+//
+// The key must not be empty or contain white space.
+// Will panic if the rank of na is not one or two.
+func (w *Writer[F]) Write(key string, na *narray.NArray[F]) (offset int64, err error) {
+
+	if key == "" || strings.ContainsAny(key, " \t\n\r") {
+		return 0, fmt.Errorf("kaldi: invalid key %q", key)
+	}
+	var b bytes.Buffer
+	b.WriteString(key + " ")
+	offset = w.cw.n + int64(b.Len())
+	appendObject(&b, na, w.binary)
+	_, err = w.cw.Write(b.Bytes())
+	return offset, err
+}
+
+// countWriter counts the bytes written to w.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// countReader counts the bytes read from r.
+type countReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
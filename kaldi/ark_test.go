@@ -0,0 +1,215 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kaldi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/akualab/narray"
+)
+
+func panics(fn func()) (ok bool) {
+	defer func() {
+		ok = recover() != nil
+	}()
+	fn()
+	return
+}
+
+// arkEntry returns a binary entry, for malformed archives.
+func arkEntry(key, token string, dims []int32, data interface{}) []byte {
+	var b bytes.Buffer
+	b.WriteString(key + " \x00B" + token + " ")
+	for _, d := range dims {
+		b.WriteByte(4)
+		binary.Write(&b, binary.LittleEndian, d)
+	}
+	binary.Write(&b, binary.LittleEndian, data)
+	return b.Bytes()
+}
+
+// The entries of the archives in testdata.
+var (
+	refKeys   = []string{"utt1", "utt2"}
+	refMatrix = narray.NewArray([]float64{0.5, -1.25, 3, 4.75, 0, -6}, 2, 3)
+	refVector = narray.NewArray([]float64{0.125, math.Inf(1), -2}, 3)
+)
+
+func TestReadBinary(t *testing.T) {
+
+	ark, err := os.ReadFile(filepath.Join("testdata", "feats.ark"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, values, err := ReadAll[float64](bytes.NewReader(ark))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(keys, " ") != strings.Join(refKeys, " ") {
+		t.Fatalf("expected keys %v, got %v", refKeys, keys)
+	}
+	if !equalNaN(values[0], refMatrix) || !equalNaN(values[1], refVector) {
+		t.Fatalf("expected %v and %v, got %v", refMatrix, refVector, values)
+	}
+
+	// Offsets point after the key.
+	r := NewReader[float32](bytes.NewReader(ark))
+	for r.Next() {
+		if off := r.Offset(); !bytes.HasPrefix(ark[off:], []byte("\x00B")) {
+			t.Fatalf("offset [%d] of %s doesn't point to the object", off, r.Key())
+		}
+	}
+
+	// Rewriting the float values gives the same bytes.
+	_, floats, err := ReadAll[float32](bytes.NewReader(ark))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	w := NewWriter[float32](&b, true)
+	for i, na := range floats {
+		w.Write(keys[i], na)
+	}
+	if !bytes.Equal(b.Bytes(), ark) {
+		t.Fatalf("expected %q, got %q", ark, b.Bytes())
+	}
+
+	cm := arkEntry("utt3", "CM", nil, []byte{1, 2, 3})
+	if _, _, err := ReadAll[float32](bytes.NewReader(cm)); !errors.Is(err, ErrCompressed) {
+		t.Fatalf("expected ErrCompressed, got %v", err)
+	}
+	if _, _, err := ReadAll[float32](bytes.NewReader(ark[:len(ark)-1])); err == nil {
+		t.Fatalf("expected an error for a truncated archive")
+	}
+	// The dimensions don't allocate more than the data.
+	for _, dims := range [][]int32{{math.MaxInt32, math.MaxInt32}, {1 << 20, 1 << 12}} {
+		huge := arkEntry("utt1", "DM", dims, []float64{1, 2})
+		if _, _, err := ReadAll[float32](bytes.NewReader(huge)); err == nil {
+			t.Fatalf("expected an error for dimensions %v larger than the data", dims)
+		}
+	}
+}
+
+func TestReadText(t *testing.T) {
+
+	ark, err := os.ReadFile(filepath.Join("testdata", "feats.txt.ark"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, values, err := ReadAll[float64](bytes.NewReader(ark))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(keys, " ") != strings.Join(refKeys, " ") {
+		t.Fatalf("expected keys %v, got %v", refKeys, keys)
+	}
+	if !equalNaN(values[0], refMatrix) || !equalNaN(values[1], refVector) {
+		t.Fatalf("expected %v and %v, got %v", refMatrix, refVector, values)
+	}
+
+	// Rewriting gives the same text.
+	var b bytes.Buffer
+	w := NewWriter[float64](&b, false)
+	for i, na := range values {
+		w.Write(keys[i], na)
+	}
+	if b.String() != string(ark) {
+		t.Fatalf("expected %q, got %q", ark, b.String())
+	}
+
+	// Empty lines, nan and empty vectors.
+	keys, values, err = ReadAll[float64](strings.NewReader("utt1  [ nan ]\n\nutt2  [ ]\n"))
+	if err != nil || strings.Join(keys, " ") != "utt1 utt2" {
+		t.Fatalf("expected keys [utt1 utt2], got %v, %v", keys, err)
+	}
+	if !math.IsNaN(values[0].At(0)) || values[1].Rank != 1 || values[1].Size() != 0 {
+		t.Fatalf("expected [nan] and an empty vector, got %v", values)
+	}
+
+	// Special values as written by C++ streams.
+	_, values, err = ReadAll[float64](strings.NewReader("utt1  [ -nan NaN -inf INF ]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := values[0].Data
+	if !math.IsNaN(v[0]) || !math.IsNaN(v[1]) || !math.IsInf(v[2], -1) || !math.IsInf(v[3], 1) {
+		t.Fatalf("expected [nan nan -inf inf], got %v", v)
+	}
+
+	for _, ark := range []string{
+		"utt1  [\n  1 2 3 \n  4 5 ]\n",
+		"utt1  [ 1 2 x ]\n",
+		"utt1  [ 1 2 3\n",
+		"utt1\n[ 1 ]\n",
+	} {
+		if _, _, err := ReadAll[float32](strings.NewReader(ark)); err == nil {
+			t.Fatalf("expected an error for %q", ark)
+		}
+	}
+}
+
+func TestWriter(t *testing.T) {
+
+	m := narray.NewArray([]float64{1, 2.5, -3, 1e-20, 5, math.NaN()}, 2, 3)
+	v := narray.NewArray([]float64{0.1, math.Inf(1)}, 2)
+	for _, bin := range []bool{true, false} {
+		var b bytes.Buffer
+		w := NewWriter[float64](&b, bin)
+		if _, err := w.Write("utt1", m.Transpose()); err != nil {
+			t.Fatal(err)
+		}
+		off, err := w.Write("utt2", v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := NewReader[float64](bytes.NewReader(b.Bytes()))
+		if !r.Next() || r.Key() != "utt1" || !equalNaN(r.Value(), m.Transpose().Copy()) {
+			t.Fatalf("binary %t: expected %v, got %s %v, %v", bin, m.Transpose(), r.Key(), r.Value(), r.Err())
+		}
+		if !r.Next() || r.Key() != "utt2" || r.Offset() != off || !equalNaN(r.Value(), v) {
+			t.Fatalf("binary %t: expected %v at [%d], got %s %v at [%d], %v", bin, v, off, r.Key(), r.Value(), r.Offset(), r.Err())
+		}
+		if r.Next() || r.Err() != nil {
+			t.Fatalf("binary %t: expected the end of the archive, got %v", bin, r.Err())
+		}
+	}
+
+	// Float32 narrays are written as float objects.
+	var b bytes.Buffer
+	NewWriter[float32](&b, true).Write("utt1", narray.New[float32](2, 2))
+	if !bytes.HasPrefix(b.Bytes(), []byte("utt1 \x00BFM ")) {
+		t.Fatalf("expected a float matrix, got %q", b.Bytes())
+	}
+	if _, err := NewWriter[float32](&b, true).Write("utt 1", narray.New[float32](2)); err == nil {
+		t.Fatalf("expected an error for a key with a space")
+	}
+	if !panics(func() { NewWriter[float32](&b, true).Write("utt1", narray.New[float32](2, 2, 2)) }) {
+		t.Fatalf("expected panic for an narray of rank three")
+	}
+}
+
+// equalNaN returns true if x and y are equal, NaN values included.
+func equalNaN(x, y *narray.NArray[float64]) bool {
+
+	if !narray.EqualShape(x, y) {
+		return false
+	}
+	for i, v := range x.Data {
+		w := y.Data[i]
+		if v != w && !(math.IsNaN(v) && math.IsNaN(w)) {
+			return false
+		}
+	}
+	return true
+}
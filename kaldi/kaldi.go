@@ -0,0 +1,317 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package kaldi reads and writes the matrices and vectors of Kaldi archives.
+
+An archive, or ark, is a sequence of entries made of a key, usually an
+utterance id, followed by an object in binary or text form. Matrices are
+narrays of rank two and vectors are narrays of rank one. Both float and
+double objects are read into narrays of either precision:
+
+	r := kaldi.NewReader[float32](f) // feats.ark
+	for r.Next() {
+		fmt.Println(r.Key(), r.Value().Shape)
+	}
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+A script file, or scp, maps keys to the positions of the objects in
+archives, "utt1 feats.ark:6". A Table reads the objects of an scp in any
+order:
+
+	t, err := kaldi.OpenTable[float64]("feats.scp")
+	if err != nil {
+		return err
+	}
+	defer t.Close()
+	feats, err := t.Get("utt1")
+
+The Writer writes entries in binary or text form and returns the offsets
+needed to write the matching scp. Float32 narrays are written as float
+objects and float64 narrays as double objects.
+
+Compressed matrices, sparse objects, pipes and ranges in scp files are
+not supported.
+*/
+package kaldi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/akualab/narray"
+)
+
+var (
+	// ErrNotFound is returned by Table.Get when a key is not in the scp.
+	ErrNotFound = errors.New("kaldi: key not found")
+	// ErrCompressed is returned when an archive holds a compressed matrix.
+	ErrCompressed = errors.New("kaldi: compressed matrices are not supported")
+)
+
+// readObject reads a matrix or a vector in binary or text form.
+func readObject[F narray.Float](br *bufio.Reader) (*narray.NArray[F], error) {
+
+	if err := skipSpace(br, false); err != nil {
+		return nil, fmt.Errorf("kaldi: missing object: %v", err)
+	}
+	b, err := br.Peek(2)
+	if err == nil && string(b) == "\x00B" {
+		br.Discard(2)
+		return readBinary[F](br)
+	}
+	return readText[F](br)
+}
+
+// readBinary reads an object in binary form after the "\0B" marker.
+func readBinary[F narray.Float](br *bufio.Reader) (*narray.NArray[F], error) {
+
+	token, err := br.ReadString(' ')
+	if err != nil {
+		return nil, fmt.Errorf("kaldi: can't read object type: %v", err)
+	}
+	token = token[:len(token)-1]
+	var dims []int
+	switch token {
+	case "FM", "DM":
+		dims = make([]int, 2, 2)
+	case "FV", "DV":
+		dims = make([]int, 1, 1)
+	case "CM", "CM2", "CM3":
+		return nil, ErrCompressed
+	default:
+		return nil, fmt.Errorf("kaldi: unsupported object type %q", token)
+	}
+	for i := range dims {
+		if dims[i], err = readInt32(br); err != nil {
+			return nil, err
+		}
+	}
+	// Read the data before allocating the narray, so that the memory used
+	// is bounded by the size of the archive and not by the dimensions.
+	size := 1
+	for _, d := range dims {
+		size *= d
+	}
+	width := 8
+	if token[0] == 'F' {
+		width = 4
+	}
+	if size > math.MaxInt/width {
+		return nil, fmt.Errorf("kaldi: %s of shape %v is too large", token, dims)
+	}
+	data, err := io.ReadAll(io.LimitReader(br, int64(size*width)))
+	if err == nil && len(data) < size*width {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("kaldi: can't read %s data of shape %v: %v", token, dims, err)
+	}
+	na := narray.New[F](dims...)
+	for i := range na.Data {
+		if width == 4 {
+			na.Data[i] = F(math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:])))
+		} else {
+			na.Data[i] = F(math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:])))
+		}
+	}
+	return na, nil
+}
+
+// readInt32 reads a binary int32 prefixed by its size.
+func readInt32(br *bufio.Reader) (int, error) {
+
+	size, err := br.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("kaldi: can't read dimension: %v", err)
+	}
+	if size != 4 {
+		return 0, fmt.Errorf("kaldi: invalid dimension size [%d]", size)
+	}
+	var v int32
+	if err := binary.Read(br, binary.LittleEndian, &v); err != nil {
+		return 0, fmt.Errorf("kaldi: can't read dimension: %v", err)
+	}
+	if v < 0 {
+		return 0, fmt.Errorf("kaldi: invalid dimension [%d]", v)
+	}
+	return int(v), nil
+}
+
+// readText reads an object in text form, a vector on a single line
+//
+//	[ 1 2 3 ]
+//
+// or a matrix with a row per line
+//
+//	[
+//	  1 2 3
+//	  4 5 6 ]
+func readText[F narray.Float](br *bufio.Reader) (*narray.NArray[F], error) {
+
+	c, err := br.ReadByte()
+	if err != nil || c != '[' {
+		return nil, fmt.Errorf("kaldi: invalid object, expected '[' or binary marker")
+	}
+	body, err := br.ReadString(']')
+	if err != nil {
+		return nil, fmt.Errorf("kaldi: unterminated text object: %v", err)
+	}
+	// Consume the end of the line.
+	if err := skipSpace(br, true); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	body = body[:len(body)-1]
+	lines := strings.Split(body, "\n")
+	if len(lines) == 1 || strings.TrimSpace(lines[0]) != "" {
+		values, err := parseValues[F](strings.Fields(body))
+		if err != nil {
+			return nil, err
+		}
+		return narray.NewArray(values, len(values)), nil
+	}
+	var values []F
+	rows, cols := 0, 0
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if rows > 0 && len(fields) != cols {
+			return nil, fmt.Errorf("kaldi: row [%d] has [%d] values, expected [%d]", rows, len(fields), cols)
+		}
+		row, err := parseValues[F](fields)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, row...)
+		rows, cols = rows+1, len(fields)
+	}
+	return narray.NewArray(values, rows, cols), nil
+}
+
+// parseValues parses the text form of floats. The special values are
+// written by C++ streams as nan, -nan, inf and -inf, strconv doesn't
+// accept -nan.
+func parseValues[F narray.Float](fields []string) ([]F, error) {
+
+	values := make([]F, len(fields), len(fields))
+	for i, s := range fields {
+		var v float64
+		switch strings.ToLower(s) {
+		case "nan", "-nan", "+nan":
+			v = math.NaN()
+		case "inf", "+inf", "infinity", "+infinity":
+			v = math.Inf(1)
+		case "-inf", "-infinity":
+			v = math.Inf(-1)
+		default:
+			var err error
+			if v, err = strconv.ParseFloat(s, 64); err != nil {
+				return nil, fmt.Errorf("kaldi: invalid value %q", s)
+			}
+		}
+		values[i] = F(v)
+	}
+	return values, nil
+}
+
+// skipSpace skips white space. If line is true, it stops after a newline.
+func skipSpace(br *bufio.Reader, line bool) error {
+
+	for {
+		c, err := br.ReadByte()
+		if err != nil {
+			return err
+		}
+		if line && c == '\n' {
+			return nil
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+			return br.UnreadByte()
+		}
+	}
+}
+
+// appendObject appends the binary or text form of a matrix or a vector to b.
+// Will panic if the rank of na is not one or two.
+func appendObject[F narray.Float](b *bytes.Buffer, na *narray.NArray[F], bin bool) {
+
+	if na.Rank != 1 && na.Rank != 2 {
+		panic(&narray.ShapeError{Op: "kaldi", Msg: "objects must be matrices or vectors", Shapes: [][]int{na.Shape}})
+	}
+	c := na.Contiguous()
+	data := c.Data[c.Offset : c.Offset+c.Size()]
+	if !bin {
+		appendText(b, data, na.Shape)
+		return
+	}
+
+	var v F
+	token := "D"
+	if unsafe.Sizeof(v) == 4 {
+		token = "F"
+	}
+	if na.Rank == 2 {
+		token += "M "
+	} else {
+		token += "V "
+	}
+	b.WriteString("\x00B" + token)
+	for _, d := range na.Shape {
+		if d > math.MaxInt32 {
+			panic(fmt.Sprintf("kaldi: dimension [%d] is too large", d))
+		}
+		b.WriteByte(4)
+		binary.Write(b, binary.LittleEndian, int32(d))
+	}
+	binary.Write(b, binary.LittleEndian, data)
+}
+
+// appendText appends the text form of a matrix or a vector to b.
+func appendText[F narray.Float](b *bytes.Buffer, data []F, shape []int) {
+
+	var v F
+	bits := int(unsafe.Sizeof(v)) * 8
+	format := func(x F) string {
+		// Inf and NaN are spelled like the output of C++ streams.
+		switch f := float64(x); {
+		case math.IsInf(f, 1):
+			return "inf "
+		case math.IsInf(f, -1):
+			return "-inf "
+		case math.IsNaN(f):
+			return "nan "
+		}
+		return strconv.FormatFloat(float64(x), 'g', -1, bits) + " "
+	}
+	if len(shape) == 1 || len(data) == 0 {
+		b.WriteString(" [ ")
+		for _, x := range data {
+			b.WriteString(format(x))
+		}
+		b.WriteString("]\n")
+		return
+	}
+	b.WriteString(" [")
+	for i, x := range data {
+		if i%shape[1] == 0 {
+			b.WriteString("\n  ")
+		}
+		b.WriteString(format(x))
+	}
+	b.WriteString("]\n")
+}
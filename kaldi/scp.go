@@ -0,0 +1,182 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kaldi
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/akualab/narray"
+)
+
+// ScpEntry is a line of an scp file, "key path:offset".
+type ScpEntry struct {
+	Key  string
+	Path string
+	// Offset is the position of the object in the file.
+	// It is zero for a line without offset, "key path".
+	Offset int64
+}
+
+// ReadScp reads the entries of an scp file. Empty lines are skipped.
+// Returns an error for pipes and ranges.
+func ReadScp(r io.Reader) ([]ScpEntry, error) {
+
+	var entries []ScpEntry
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("kaldi: invalid scp line [%d]: %q", n, line)
+		}
+		e := ScpEntry{Key: fields[0], Path: fields[1]}
+		if strings.HasSuffix(e.Path, "|") || strings.HasSuffix(e.Path, "]") {
+			return nil, fmt.Errorf("kaldi: unsupported scp path %q on line [%d]", e.Path, n)
+		}
+		if i := strings.LastIndexByte(e.Path, ':'); i >= 0 {
+			off, err := strconv.ParseInt(e.Path[i+1:], 10, 64)
+			if err != nil || off < 0 {
+				return nil, fmt.Errorf("kaldi: invalid offset in scp line [%d]: %q", n, line)
+			}
+			e.Path, e.Offset = e.Path[:i], off
+		}
+		entries = append(entries, e)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ReadScpFile reads the entries of an scp file. See ReadScp.
+func ReadScpFile(fn string) ([]ScpEntry, error) {
+
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadScp(f)
+}
+
+// WriteScp writes the entries of an scp file.
+func WriteScp(w io.Writer, entries []ScpEntry) error {
+
+	bw := bufio.NewWriter(w)
+	for _, e := range entries {
+		if _, err := fmt.Fprintf(bw, "%s %s:%d\n", e.Key, e.Path, e.Offset); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// Table reads the objects of an scp file by key. The archives are opened
+// when needed and stay open until Close is called. Get can be called from
+// multiple goroutines.
+type Table[F narray.Float] struct {
+	entries []ScpEntry
+	index   map[string]int
+	mu      sync.Mutex
+	files   map[string]*os.File
+}
+
+// NewTable returns a table of the scp entries. If a key is repeated,
+// the last entry is used.
+func NewTable[F narray.Float](entries []ScpEntry) *Table[F] {
+
+	t := &Table[F]{
+		entries: entries,
+		index:   make(map[string]int, len(entries)),
+		files:   make(map[string]*os.File),
+	}
+	for i, e := range entries {
+		t.index[e.Key] = i
+	}
+	return t
+}
+
+// OpenTable reads the scp file fn and returns its table.
+func OpenTable[F narray.Float](fn string) (*Table[F], error) {
+
+	entries, err := ReadScpFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	return NewTable[F](entries), nil
+}
+
+// Keys returns the keys of the table in the order of the scp file.
+func (t *Table[F]) Keys() []string {
+
+	keys := make([]string, len(t.entries), len(t.entries))
+	for i, e := range t.entries {
+		keys[i] = e.Key
+	}
+	return keys
+}
+
+// Get reads the object of key. Returns ErrNotFound if the key is not in
+// the table.
+func (t *Table[F]) Get(key string) (*narray.NArray[F], error) {
+
+	i, ok := t.index[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, key)
+	}
+	e := t.entries[i]
+	f, err := t.open(e.Path)
+	if err != nil {
+		return nil, err
+	}
+	sr := io.NewSectionReader(f, e.Offset, math.MaxInt64-e.Offset)
+	na, err := readObject[F](bufio.NewReader(sr))
+	if err != nil {
+		return nil, fmt.Errorf("%w, key %q in %s", err, key, e.Path)
+	}
+	return na, nil
+}
+
+// open returns the open file of path.
+func (t *Table[F]) open(path string) (*os.File, error) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if f, ok := t.files[path]; ok {
+		return f, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	t.files[path] = f
+	return f, nil
+}
+
+// Close closes the archives opened by Get.
+func (t *Table[F]) Close() error {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var err error
+	for path, f := range t.files {
+		if e := f.Close(); e != nil && err == nil {
+			err = e
+		}
+		delete(t.files, path)
+	}
+	return err
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kaldi
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/akualab/narray"
+)
+
+func TestScp(t *testing.T) {
+
+	scp := "utt1 feats.ark:5\n\nutt2 /data/feats.ark:1234\nutt3 vec.mat\nutt4 /data/a:b/feats.ark:42\n"
+	entries, err := ReadScp(strings.NewReader(scp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []ScpEntry{
+		{Key: "utt1", Path: "feats.ark", Offset: 5},
+		{Key: "utt2", Path: "/data/feats.ark", Offset: 1234},
+		{Key: "utt3", Path: "vec.mat", Offset: 0},
+		{Key: "utt4", Path: "/data/a:b/feats.ark", Offset: 42},
+	}
+	if !reflect.DeepEqual(entries, exp) {
+		t.Fatalf("expected %v, got %v", exp, entries)
+	}
+	var b bytes.Buffer
+	if err := WriteScp(&b, entries); err != nil {
+		t.Fatal(err)
+	}
+	if res, _ := ReadScp(&b); !reflect.DeepEqual(res, exp) {
+		t.Fatalf("expected %v, got %v", exp, res)
+	}
+
+	for _, scp := range []string{
+		"utt1 gunzip -c feats.ark.gz |\n",
+		"utt1 feats.ark:5[0:9]\n",
+		"utt1 feats.ark:x\n",
+		"utt1\n",
+	} {
+		if _, err := ReadScp(strings.NewReader(scp)); err == nil {
+			t.Fatalf("expected an error for %q", scp)
+		}
+	}
+}
+
+func TestTable(t *testing.T) {
+
+	dir, err := os.MkdirTemp("", "kaldi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Two archives, one in binary and one in text form.
+	r := rand.New(rand.NewSource(7))
+	values := map[string]*narray.NArray[float32]{}
+	var entries []ScpEntry
+	for k, bin := range []bool{true, false} {
+		path := filepath.Join(dir, []string{"a.ark", "b.ark"}[k])
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := NewWriter[float32](f, bin)
+		for i := 0; i < 3; i++ {
+			key := string(rune('a'+k)) + string(rune('0'+i))
+			values[key] = narray.Norm[float32](r, 0, 1, 4+i, 3)
+			if i == 2 {
+				values[key] = narray.Norm[float32](r, 0, 1, 5)
+			}
+			off, err := w.Write(key, values[key])
+			if err != nil {
+				t.Fatal(err)
+			}
+			entries = append(entries, ScpEntry{Key: key, Path: path, Offset: off})
+		}
+		f.Close()
+	}
+	scp := filepath.Join(dir, "feats.scp")
+	f, err := os.Create(scp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	WriteScp(f, entries)
+	f.Close()
+
+	tab, err := OpenTable[float32](scp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tab.Close()
+	keys := tab.Keys()
+	if strings.Join(keys, " ") != "a0 a1 a2 b0 b1 b2" {
+		t.Fatalf("expected keys in scp order, got %v", keys)
+	}
+	for i := len(keys) - 1; i >= 0; i-- {
+		na, err := tab.Get(keys[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The text form is exact for float32 values.
+		if !narray.EqualValues(na, values[keys[i]], 0) {
+			t.Fatalf("expected %v, got %v", values[keys[i]], na)
+		}
+	}
+	if _, err := tab.Get("c0"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	// The reference archive, in reverse order.
+	ref, err := OpenTable[float64](filepath.Join("testdata", "feats.scp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ref.Close()
+	v, err := ref.Get("utt2")
+	if err != nil || !equalNaN(v, refVector) {
+		t.Fatalf("expected %v, got %v, %v", refVector, v, err)
+	}
+	m, err := ref.Get("utt1")
+	if err != nil || !equalNaN(m, refMatrix) {
+		t.Fatalf("expected %v, got %v, %v", refMatrix, m, err)
+	}
+}
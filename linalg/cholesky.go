@@ -0,0 +1,118 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+
+	"github.com/akualab/narray"
+)
+
+// Cholesky computes the Cholesky factorization of symmetric positive
+// definite matrices.
+//
+//	in = L * L'
+//
+// The result is the lower triangular matrix L, the values above the
+// diagonal are zero. Only the lower triangle of in is used.
+//
+// If out is nil a new array is created. Out may be in.
+// Returns ErrNotPositiveDefinite if a matrix is not positive definite, the
+// factor of that matrix is not valid.
+// Will panic if the matrices are not square or if the shape of out doesn't match.
+func Cholesky[F narray.Float](out, in *narray.NArray[F]) (*narray.NArray[F], error) {
+
+	s := loadSquare(in, "Cholesky")
+	var err error
+	for _, a := range s.mats {
+		if !cholesky(a, s.n) {
+			err = ErrNotPositiveDefinite
+		}
+	}
+	return store(out, s.shape(), s.mats, "Cholesky"), err
+}
+
+// SolveTriangular solves the linear systems t * x = b, where t are lower
+// triangular matrices if lower is true and upper triangular matrices otherwise.
+// Only the triangle of t is used. See Solve for the shapes of b and x.
+//
+// If out is nil a new array is created. Out may be b.
+// Returns ErrSingular if a value on the diagonal of t is zero relative to
+// the largest value of the triangle, see LU.
+// Will panic if the shapes don't match.
+func SolveTriangular[F narray.Float](out, t, b *narray.NArray[F], lower bool) (*narray.NArray[F], error) {
+
+	st := loadSquare(t, "SolveTriangular")
+	sb := loadRHS(st, b, "SolveTriangular")
+	solve := solveUpper
+	if lower {
+		solve = solveLower
+	}
+	var err error
+	for k, m := range st.mats {
+		if !solve(m, sb.mats[k], st.n, sb.n, false) {
+			err = ErrSingular
+		}
+	}
+	return store(out, b.Shape, sb.mats, "SolveTriangular"), err
+}
+
+// SolveCholesky solves the linear systems a * x = b given the Cholesky
+// factors l of a, as computed by Cholesky. See Solve for the shapes of b and x.
+//
+// If out is nil a new array is created. Out may be b.
+// Returns ErrSingular if a value on the diagonal of l is zero relative to
+// the largest value of l, see LU.
+// Will panic if the shapes don't match.
+func SolveCholesky[F narray.Float](out, l, b *narray.NArray[F]) (*narray.NArray[F], error) {
+
+	sl := loadSquare(l, "SolveCholesky")
+	sb := loadRHS(sl, b, "SolveCholesky")
+	n := sl.n
+	lt := make([]float64, n*n, n*n)
+	var err error
+	for k, m := range sl.mats {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				lt[j*n+i] = m[i*n+j]
+			}
+		}
+		if !solveLower(m, sb.mats[k], n, sb.n, false) || !solveUpper(lt, sb.mats[k], n, sb.n, false) {
+			err = ErrSingular
+		}
+	}
+	return store(out, b.Shape, sb.mats, "SolveCholesky"), err
+}
+
+// cholesky computes the Cholesky factor of the n x n matrix a in place.
+// Returns false if a is not positive definite.
+func cholesky(a []float64, n int) bool {
+
+	for j := 0; j < n; j++ {
+		rj := a[j*n : j*n+j]
+		d := a[j*n+j]
+		for _, v := range rj {
+			d -= v * v
+		}
+		if !(d > 0) {
+			return false
+		}
+		d = math.Sqrt(d)
+		a[j*n+j] = d
+		for i := j + 1; i < n; i++ {
+			ri := a[i*n : i*n+j]
+			v := a[i*n+j]
+			for c, w := range rj {
+				v -= ri[c] * w
+			}
+			a[i*n+j] = v / d
+		}
+		for c := j + 1; c < n; c++ {
+			a[j*n+c] = 0
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/akualab/narray"
+)
+
+// covariance returns a random positive definite matrix.
+func covariance(r *rand.Rand, n int) *narray.NArray[float64] {
+	x := narray.Norm[float64](r, 0, 1, 2*n, n)
+	return narray.MatMul(nil, x.Transpose(), x)
+}
+
+func TestCholesky(t *testing.T) {
+
+	r := rand.New(rand.NewSource(44))
+	cov := covariance(r, 6)
+	l, err := Cholesky(nil, cov)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 6; i++ {
+		for j := i + 1; j < 6; j++ {
+			if l.At(i, j) != 0 {
+				t.Fatalf("expected zero above the diagonal at [%d %d], got %f", i, j, l.At(i, j))
+			}
+		}
+	}
+	if !narray.EqualValues(narray.MatMul(nil, l, l.Transpose()), cov, 1e-9) {
+		t.Fatalf("L * L' doesn't match")
+	}
+
+	// The log determinant is twice the sum of the logs of the diagonal.
+	sum := 0.0
+	for i := 0; i < 6; i++ {
+		sum += 2 * math.Log(l.At(i, i))
+	}
+	if ld, _ := LogDet(cov); math.Abs(ld.At()-sum) > 1e-9 {
+		t.Fatalf("expected %f, got %f", sum, ld.At())
+	}
+
+	notPD := narray.NewArray([]float64{1, 2, 2, 1}, 2, 2)
+	if _, err := Cholesky(nil, notPD); err != ErrNotPositiveDefinite {
+		t.Fatalf("expected ErrNotPositiveDefinite, got %v", err)
+	}
+	if _, err := Cholesky(nil, narray.New[float64](3, 3)); err != ErrNotPositiveDefinite {
+		t.Fatalf("expected ErrNotPositiveDefinite, got %v", err)
+	}
+}
+
+func TestSolveTriangular(t *testing.T) {
+
+	r := rand.New(rand.NewSource(55))
+	l, _ := Cholesky(nil, covariance(r, 5))
+	b := narray.Norm[float64](r, 0, 1, 5, 2)
+	x, err := SolveTriangular(nil, l, b, true)
+	if err != nil || !narray.EqualValues(narray.MatMul(nil, l, x), b, 1e-9) {
+		t.Fatalf("lower: unexpected solution %v, %v", x, err)
+	}
+	u := l.Transpose().Copy()
+	x, err = SolveTriangular(nil, u, b, false)
+	if err != nil || !narray.EqualValues(narray.MatMul(nil, u, x), b, 1e-9) {
+		t.Fatalf("upper: unexpected solution %v, %v", x, err)
+	}
+	// Only the triangle is used.
+	full := narray.Add(nil, l, u)
+	for i := 0; i < 5; i++ {
+		full.Set(l.At(i, i), i, i)
+	}
+	if y, _ := SolveTriangular(nil, full, b, false); !narray.EqualValues(y, x, 1e-12) {
+		t.Fatalf("expected %v, got %v", x, y)
+	}
+	if _, err := SolveTriangular(nil, narray.New[float64](5, 5), b, true); err != ErrSingular {
+		t.Fatalf("expected ErrSingular, got %v", err)
+	}
+}
+
+func TestSolveCholesky(t *testing.T) {
+
+	r := rand.New(rand.NewSource(66))
+	cov := narray.New[float64](3, 4, 4)
+	for k := 0; k < 3; k++ {
+		ck := cov.SubArray(k, -1, -1)
+		narray.Add(ck, ck, covariance(r, 4))
+	}
+	l, err := Cholesky(nil, cov)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := narray.Norm[float64](r, 0, 1, 3, 4)
+	x, err := SolveCholesky(nil, l, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp, _ := Solve(nil, cov, b)
+	if !narray.EqualValues(x, exp, 1e-9) {
+		t.Fatalf("expected %v, got %v", exp, x)
+	}
+}
@@ -0,0 +1,328 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+	"sort"
+
+	"github.com/akualab/narray"
+)
+
+// maxSweeps is the maximum number of sweeps of the Jacobi methods.
+const maxSweeps = 100
+
+// EigSym computes the eigenvalues and eigenvectors of symmetric matrices.
+//
+//	in = V * diag(w) * V'
+//
+// The eigenvalues w are in ascending order and the columns of V are the
+// corresponding orthonormal eigenvectors. Only the lower triangle of in is used.
+// The decomposition uses the cyclic Jacobi method.
+//
+// If w or v are nil new arrays are created.
+// Returns ErrNoConvergence if the method doesn't converge.
+// Will panic if the matrices are not square or if the shapes of w or v don't match.
+func EigSym[F narray.Float](w, v, in *narray.NArray[F]) (*narray.NArray[F], *narray.NArray[F], error) {
+
+	s := loadSquare(in, "EigSym")
+	n := s.n
+	ws := make([]float64, len(s.mats)*n, len(s.mats)*n)
+	vs := make([][]float64, len(s.mats), len(s.mats))
+	var err error
+	for k, a := range s.mats {
+		var ok bool
+		vs[k], ok = jacobiEig(a, ws[k*n:k*n+n], n)
+		if !ok {
+			err = ErrNoConvergence
+		}
+	}
+	w = store(w, append(append([]int{}, s.batch...), n), [][]float64{ws}, "EigSym")
+	v = store(v, s.shape(), vs, "EigSym")
+	return w, v, err
+}
+
+// SVD computes the singular value decomposition of m x n matrices.
+//
+//	in = U * diag(s) * Vt
+//
+// The singular values s are non-negative and in descending order, there are
+// k = min(m, n) of them. If full is false, U is m x k and Vt is k x n. If full
+// is true, U is m x m and Vt is n x n. The columns of U and the rows of Vt
+// are orthonormal. The decomposition uses the one-sided Jacobi method.
+//
+// If u, s or vt are nil new arrays are created.
+// Returns ErrNoConvergence if the method doesn't converge.
+// Will panic if the rank of in is less than two or if the shapes of u, s or
+// vt don't match.
+func SVD[F narray.Float](u, s, vt, in *narray.NArray[F], full bool) (*narray.NArray[F], *narray.NArray[F], *narray.NArray[F], error) {
+
+	st := load(in, "SVD")
+	m, n := st.m, st.n
+	k := m
+	if n < k {
+		k = n
+	}
+	um, vm := k, k
+	if full {
+		um, vm = m, n
+	}
+	us := make([][]float64, len(st.mats), len(st.mats))
+	ss := make([]float64, len(st.mats)*k, len(st.mats)*k)
+	vts := make([][]float64, len(st.mats), len(st.mats))
+	var err error
+	for b, a := range st.mats {
+		var uu, vv []float64
+		var ok bool
+		if m >= n {
+			uu, vv, ok = jacobiSVD(a, ss[b*k:b*k+k], m, n, um)
+		} else {
+			// Decompose the transpose, in' = V * diag(s) * U'.
+			at := make([]float64, m*n, m*n)
+			for i := 0; i < m; i++ {
+				for j := 0; j < n; j++ {
+					at[j*m+i] = a[i*n+j]
+				}
+			}
+			vv, uu, ok = jacobiSVD(at, ss[b*k:b*k+k], n, m, vm)
+		}
+		if !ok {
+			err = ErrNoConvergence
+		}
+		// Vt is the transpose of the first vm columns of V.
+		us[b] = uu
+		vts[b] = make([]float64, vm*n, vm*n)
+		cols := len(vv) / n
+		for i := 0; i < n; i++ {
+			for j := 0; j < vm && j < cols; j++ {
+				vts[b][j*n+i] = vv[i*cols+j]
+			}
+		}
+	}
+	u = store(u, append(append([]int{}, st.batch...), m, um), us, "SVD")
+	s = store(s, append(append([]int{}, st.batch...), k), [][]float64{ss}, "SVD")
+	vt = store(vt, append(append([]int{}, st.batch...), vm, n), vts, "SVD")
+	return u, s, vt, err
+}
+
+// jacobiEig computes the eigenvalues of the symmetric n x n matrix a, which
+// is overwritten, into w and returns the eigenvectors in the columns of an
+// n x n matrix. Returns false if the method doesn't converge.
+func jacobiEig(a, w []float64, n int) ([]float64, bool) {
+
+	// Copy the lower triangle to the upper triangle.
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			a[i*n+j] = a[j*n+i]
+		}
+	}
+	v := identity(n, n)
+	ok := true
+	for sweep := 0; !isDiagonal(a, n); sweep++ {
+		if sweep == maxSweeps {
+			ok = false
+			break
+		}
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				apq := a[p*n+q]
+				if apq == 0 {
+					continue
+				}
+				// The rotation that zeroes a[p][q].
+				theta := (a[q*n+q] - a[p*n+p]) / (2 * apq)
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for i := 0; i < n; i++ {
+					aip, aiq := a[i*n+p], a[i*n+q]
+					a[i*n+p], a[i*n+q] = c*aip-s*aiq, s*aip+c*aiq
+				}
+				for i := 0; i < n; i++ {
+					api, aqi := a[p*n+i], a[q*n+i]
+					a[p*n+i], a[q*n+i] = c*api-s*aqi, s*api+c*aqi
+				}
+				for i := 0; i < n; i++ {
+					vip, viq := v[i*n+p], v[i*n+q]
+					v[i*n+p], v[i*n+q] = c*vip-s*viq, s*vip+c*viq
+				}
+			}
+		}
+	}
+	for i := range w {
+		w[i] = a[i*n+i]
+	}
+	order := sortedOrder(w, false)
+	return permuteColumns(v, order, n, n), ok
+}
+
+// isDiagonal reports whether the off-diagonal values of the n x n matrix a
+// are negligible.
+func isDiagonal(a []float64, n int) bool {
+
+	off, norm := 0.0, 0.0
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			x := a[i*n+j] * a[i*n+j]
+			norm += x
+			if i != j {
+				off += x
+			}
+		}
+	}
+	return off <= 1e-30*norm
+}
+
+// jacobiSVD computes the singular values of the m x n matrix a, m >= n,
+// which is overwritten, into s. Returns U with uc columns, uc is n or m,
+// and V, n x n. Returns false if the method doesn't converge.
+func jacobiSVD(a, s []float64, m, n, uc int) (u, v []float64, ok bool) {
+
+	// Rotate pairs of columns of a until they are orthogonal, then
+	// a = U * diag(s) and the rotations are V.
+	v = identity(n, n)
+	for sweep := 0; sweep < maxSweeps && !ok; sweep++ {
+		ok = true
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				alpha, beta, gamma := 0.0, 0.0, 0.0
+				for i := 0; i < m; i++ {
+					x, y := a[i*n+p], a[i*n+q]
+					alpha += x * x
+					beta += y * y
+					gamma += x * y
+				}
+				if gamma == 0 || math.Abs(gamma) <= 1e-15*math.Sqrt(alpha*beta) {
+					continue
+				}
+				ok = false
+				zeta := (beta - alpha) / (2 * gamma)
+				t := 1 / (math.Abs(zeta) + math.Sqrt(zeta*zeta+1))
+				if zeta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				sn := t * c
+				for i := 0; i < m; i++ {
+					x, y := a[i*n+p], a[i*n+q]
+					a[i*n+p], a[i*n+q] = c*x-sn*y, sn*x+c*y
+				}
+				for i := 0; i < n; i++ {
+					x, y := v[i*n+p], v[i*n+q]
+					v[i*n+p], v[i*n+q] = c*x-sn*y, sn*x+c*y
+				}
+			}
+		}
+	}
+
+	for j := 0; j < n; j++ {
+		norm := 0.0
+		for i := 0; i < m; i++ {
+			norm += a[i*n+j] * a[i*n+j]
+		}
+		s[j] = math.Sqrt(norm)
+	}
+	order := sortedOrder(s, true)
+	a = permuteColumns(a, order, m, n)
+	v = permuteColumns(v, order, n, n)
+
+	// Normalize the columns of U. The columns of zero singular values,
+	// and the extra columns of a full U, are completed to an orthonormal basis.
+	u = make([]float64, m*uc, m*uc)
+	r := 0
+	for j := 0; j < n && s[j] > 1e-15*s[0]; j++ {
+		for i := 0; i < m; i++ {
+			u[i*uc+j] = a[i*n+j] / s[j]
+		}
+		r++
+	}
+	complete(u, m, uc, r)
+	return u, v, ok
+}
+
+// sortedOrder sorts x and returns the original position of each value.
+func sortedOrder(x []float64, descending bool) []int {
+
+	order := make([]int, len(x), len(x))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if descending {
+			return x[order[i]] > x[order[j]]
+		}
+		return x[order[i]] < x[order[j]]
+	})
+	sorted := make([]float64, len(x), len(x))
+	for i, k := range order {
+		sorted[i] = x[k]
+	}
+	copy(x, sorted)
+	return order
+}
+
+// permuteColumns returns the m x n matrix a with column j set to column order[j] of a.
+func permuteColumns(a []float64, order []int, m, n int) []float64 {
+
+	res := make([]float64, m*n, m*n)
+	for i := 0; i < m; i++ {
+		for j, k := range order {
+			res[i*n+j] = a[i*n+k]
+		}
+	}
+	return res
+}
+
+// identity returns the first n columns of the m x m identity matrix.
+func identity(m, n int) []float64 {
+
+	res := make([]float64, m*n, m*n)
+	for i := 0; i < m && i < n; i++ {
+		res[i*n+i] = 1
+	}
+	return res
+}
+
+// complete sets the columns from r of the m x n matrix q to orthonormal
+// vectors that are also orthogonal to its first r orthonormal columns.
+// The vectors are computed by Gram-Schmidt on the columns of the identity.
+func complete(q []float64, m, n, r int) {
+
+	x := make([]float64, m, m)
+	for e := 0; r < n && e < m; e++ {
+		for i := range x {
+			x[i] = 0
+		}
+		x[e] = 1
+		// Orthogonalize twice for accuracy.
+		for pass := 0; pass < 2; pass++ {
+			for j := 0; j < r; j++ {
+				dot := 0.0
+				for i := 0; i < m; i++ {
+					dot += q[i*n+j] * x[i]
+				}
+				for i := 0; i < m; i++ {
+					x[i] -= dot * q[i*n+j]
+				}
+			}
+		}
+		norm := 0.0
+		for _, v := range x {
+			norm += v * v
+		}
+		norm = math.Sqrt(norm)
+		if norm < 1e-8 {
+			continue
+		}
+		for i := 0; i < m; i++ {
+			q[i*n+r] = x[i] / norm
+		}
+		r++
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/akualab/narray"
+)
+
+// eye returns the n x n identity matrix.
+func eye(n int) *narray.NArray[float64] {
+	res := narray.New[float64](n, n)
+	for i := 0; i < n; i++ {
+		res.Set(1, i, i)
+	}
+	return res
+}
+
+// diag returns the square matrix with the values of v on the diagonal.
+func diag(v *narray.NArray[float64]) *narray.NArray[float64] {
+	n := v.Shape[0]
+	res := narray.New[float64](n, n)
+	for i := 0; i < n; i++ {
+		res.Set(v.At(i), i, i)
+	}
+	return res
+}
+
+func TestEigSym(t *testing.T) {
+
+	r := rand.New(rand.NewSource(88))
+	a := covariance(r, 7)
+	// Only the lower triangle is used.
+	in := a.Copy()
+	in.Set(100, 0, 6)
+	w, v, err := EigSym(nil, nil, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < 7; i++ {
+		if w.At(i) < w.At(i-1) {
+			t.Fatalf("eigenvalues are not in ascending order: %v", w)
+		}
+	}
+	if !narray.EqualValues(narray.MatMul(nil, v.Transpose(), v), eye(7), 1e-9) {
+		t.Fatalf("eigenvectors are not orthonormal")
+	}
+	rec := narray.MatMul(nil, narray.MatMul(nil, v, diag(w)), v.Transpose())
+	if !narray.EqualValues(rec, a, 1e-9) {
+		t.Fatalf("V * diag(w) * V' doesn't match")
+	}
+
+	// A stack with a known decomposition.
+	b := narray.NewArray([]float32{2, 1, 1, 2, 4, 0, 0, 1}, 2, 2, 2)
+	wb, _, err := EigSym(nil, nil, b)
+	exp := narray.NewArray([]float32{1, 3, 1, 4}, 2, 2)
+	if err != nil || !narray.EqualValues(wb, exp, 1e-6) {
+		t.Fatalf("expected %v, got %v, %v", exp, wb, err)
+	}
+}
+
+func TestSVD(t *testing.T) {
+
+	r := rand.New(rand.NewSource(99))
+	for _, s := range [][2]int{[2]int{6, 4}, [2]int{4, 6}, [2]int{5, 5}} {
+		m, n := s[0], s[1]
+		k := int(math.Min(float64(m), float64(n)))
+		a := narray.Norm[float64](r, 0, 1, m, n)
+		for _, full := range []bool{false, true} {
+			u, sv, vt, err := SVD(nil, nil, nil, a, full)
+			if err != nil {
+				t.Fatal(err)
+			}
+			um, vm := k, k
+			if full {
+				um, vm = m, n
+			}
+			if u.Shape[1] != um || sv.Shape[0] != k || vt.Shape[0] != vm {
+				t.Fatalf("%v %t: unexpected shapes %v %v %v", s, full, u.Shape, sv.Shape, vt.Shape)
+			}
+			for i := 1; i < k; i++ {
+				if sv.At(i) > sv.At(i-1) || sv.At(i) < 0 {
+					t.Fatalf("%v: singular values are not in descending order: %v", s, sv)
+				}
+			}
+			if !narray.EqualValues(narray.MatMul(nil, u.Transpose(), u), eye(um), 1e-9) {
+				t.Fatalf("%v %t: columns of U are not orthonormal", s, full)
+			}
+			if !narray.EqualValues(narray.MatMul(nil, vt, vt.Transpose()), eye(vm), 1e-9) {
+				t.Fatalf("%v %t: rows of Vt are not orthonormal", s, full)
+			}
+			rec := narray.New[float64](m, n)
+			for j := 0; j < k; j++ {
+				for p := 0; p < m; p++ {
+					for q := 0; q < n; q++ {
+						rec.Set(rec.At(p, q)+u.At(p, j)*sv.At(j)*vt.At(j, q), p, q)
+					}
+				}
+			}
+			if !narray.EqualValues(rec, a, 1e-9) {
+				t.Fatalf("%v %t: U * diag(s) * Vt doesn't match", s, full)
+			}
+		}
+	}
+
+	// A rank deficient stack.
+	x := narray.Norm[float64](r, 0, 1, 2, 5, 1)
+	a := narray.MatMul(nil, x, narray.Norm[float64](r, 0, 1, 1, 3))
+	u, sv, _, err := SVD(nil, nil, nil, a, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for b := 0; b < 2; b++ {
+		if sv.At(b, 1) > 1e-12 || sv.At(b, 2) > 1e-12 {
+			t.Fatalf("expected one non-zero singular value, got %v", sv)
+		}
+		ub := u.SubArray(b, -1, -1)
+		if !narray.EqualValues(narray.MatMul(nil, ub.Transpose(), ub), eye(5), 1e-9) {
+			t.Fatalf("columns of U are not orthonormal")
+		}
+	}
+}
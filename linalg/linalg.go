@@ -0,0 +1,165 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package linalg computes factorizations of dense matrices and solves linear systems.
+It also computes symmetric eigendecompositions and singular value decompositions,
+and principal component analysis on top of them, see FitPCA.
+
+Matrices are narrays of rank two. Narrays of rank three or more are stacks
+of matrices in the last two axes and each matrix is processed independently:
+
+	a := na64.New(10, 3, 3)  // ten 3x3 matrices
+	det := linalg.Det(a)     // shape [10]
+
+The computations are done in float64 precision for all element types.
+Shape problems panic with a *narray.ShapeError. Numerical problems, such
+as a singular matrix, are returned as errors.
+
+The log-likelihood of a vector x of size d under a Gaussian with mean mu
+and full covariance cov uses the Cholesky factor cov = L * L':
+
+	l, err := linalg.Cholesky(nil, cov)
+	if err != nil {
+		return err // not a covariance matrix
+	}
+	z, _ := linalg.SolveTriangular(nil, l, na64.Sub(nil, x, mu), true)
+	logdet, _ := linalg.LogDet(cov)
+	ll := -0.5 * (na64.Dot(z, z) + logdet.At() + float64(d)*math.Log(2*math.Pi))
+*/
+package linalg
+
+import (
+	"errors"
+
+	"github.com/akualab/narray"
+)
+
+var (
+	// ErrSingular is returned when a matrix is singular.
+	ErrSingular = errors.New("linalg: matrix is singular")
+	// ErrNotPositiveDefinite is returned by Cholesky when a matrix is not positive definite.
+	ErrNotPositiveDefinite = errors.New("linalg: matrix is not positive definite")
+	// ErrNoConvergence is returned when an iterative method doesn't converge.
+	ErrNoConvergence = errors.New("linalg: no convergence")
+)
+
+// stack holds a copy of the matrices of an narray in float64 precision.
+type stack struct {
+	// batch is the shape of the leading axes.
+	batch []int
+	m, n  int
+	// mats are the m x n matrices in row-major order.
+	mats [][]float64
+}
+
+// load copies the matrices in the last two axes of in.
+// Will panic if the rank of in is less than two.
+func load[F narray.Float](in *narray.NArray[F], op string) *stack {
+
+	if in.Rank < 2 {
+		panic(&narray.ShapeError{Op: op, Msg: "narray must have rank two or more", Shapes: [][]int{in.Shape}})
+	}
+	s := &stack{
+		batch: in.Shape[:in.Rank-2],
+		m:     in.Shape[in.Rank-2],
+		n:     in.Shape[in.Rank-1],
+	}
+	nb := 1
+	for _, v := range s.batch {
+		nb *= v
+	}
+	size := s.m * s.n
+	s.mats = make([][]float64, nb, nb)
+	for k := range s.mats {
+		s.mats[k] = make([]float64, size, size)
+	}
+	k := 0
+	it := in.Iter()
+	for it.Next() {
+		s.mats[k/size][k%size] = float64(in.Data[it.Offset()])
+		k++
+	}
+	return s
+}
+
+// loadSquare is load for square matrices.
+// Will panic if the matrices are not square.
+func loadSquare[F narray.Float](in *narray.NArray[F], op string) *stack {
+
+	s := load(in, op)
+	if s.m != s.n {
+		panic(&narray.ShapeError{Op: op, Msg: "matrices must be square", Shapes: [][]int{in.Shape}})
+	}
+	return s
+}
+
+// loadRHS loads the right-hand side b of a linear system with matrices a.
+// If b has one axis less than a, it is a stack of vectors that are loaded as
+// matrices with one column.
+// Will panic if the shapes of a and b don't match.
+func loadRHS[F narray.Float](a *stack, b *narray.NArray[F], op string) *stack {
+
+	bm := b
+	if b.Rank == len(a.batch)+1 {
+		bm = b.Reshape(append(append([]int{}, b.Shape...), 1)...)
+	}
+	if bm.Rank != len(a.batch)+2 || !equalInts(bm.Shape[:bm.Rank-2], a.batch) || bm.Shape[bm.Rank-2] != a.n {
+		panic(&narray.ShapeError{Op: op, Msg: "right-hand side doesn't match matrices", Shapes: [][]int{a.shape(), b.Shape}})
+	}
+	return load(bm, op)
+}
+
+// shape returns the shape of the narray of the stack.
+func (s *stack) shape() []int {
+	return append(append([]int{}, s.batch...), s.m, s.n)
+}
+
+// store copies the values of mats, in order, to out. If out is nil a new
+// narray is created with shape.
+// Will panic if the shape of out doesn't match.
+func store[F narray.Float](out *narray.NArray[F], shape []int, mats [][]float64, op string) *narray.NArray[F] {
+
+	if out == nil {
+		out = narray.New[F](shape...)
+	} else if !equalInts(out.Shape, shape) {
+		panic(&narray.ShapeError{Op: op, Msg: "out shape doesn't match result shape", Shapes: [][]int{out.Shape, shape}})
+	}
+	if len(mats) == 0 {
+		return out
+	}
+	size := out.Size() / len(mats)
+	k := 0
+	it := out.Iter()
+	for it.Next() {
+		out.Data[it.Offset()] = F(mats[k/size][k%size])
+		k++
+	}
+	return out
+}
+
+// scalars returns one matrix of size one per value, to store values
+// computed for each matrix of a stack.
+func scalars(values []float64) [][]float64 {
+
+	mats := make([][]float64, len(values), len(values))
+	for k := range values {
+		mats[k] = values[k : k+1]
+	}
+	return mats
+}
+
+func equalInts(a, b []int) bool {
+
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if v != b[k] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+
+	"github.com/akualab/narray"
+)
+
+// LU computes the LU factorization with partial pivoting of square matrices.
+//
+//	P * in = L * U
+//
+// The result holds U on and above the diagonal and L, whose diagonal is one,
+// below the diagonal. The permutation is returned in piv: row i of P * in
+// is row piv[i] of in. For a stack of matrices, the pivots of matrix k are
+// piv[k*n : k*n+n].
+//
+// If out is nil a new array is created. Out may be in.
+// Returns ErrSingular if a pivot is negligible, |u_jj| <= n * eps * max|in_ij|
+// where eps is the float64 machine epsilon, the factors are still valid.
+// Will panic if the matrices are not square or if the shape of out doesn't match.
+func LU[F narray.Float](out, in *narray.NArray[F]) (lu *narray.NArray[F], piv []int, err error) {
+
+	s := loadSquare(in, "LU")
+	n := s.n
+	piv = make([]int, len(s.mats)*n, len(s.mats)*n)
+	for k, a := range s.mats {
+		if _, ok := luFactor(a, piv[k*n:k*n+n], n); !ok {
+			err = ErrSingular
+		}
+	}
+	return store(out, s.shape(), s.mats, "LU"), piv, err
+}
+
+// Solve solves the linear systems a * x = b.
+//
+// If b has the same rank as a, the columns of b are the right-hand sides and
+// x has the shape of b. If b has one axis less than a, b holds vectors. For
+// a stack of matrices, the leading axes of a and b must match.
+//
+//	a          b          x
+//	-------------------------------
+//	3x3        3          3
+//	3x3        3x5        3x5
+//	10x3x3     10x3       10x3
+//	10x3x3     10x3x5     10x3x5
+//
+// If out is nil a new array is created.
+// Returns ErrSingular if a matrix is singular.
+// Will panic if the shapes don't match.
+func Solve[F narray.Float](out, a, b *narray.NArray[F]) (*narray.NArray[F], error) {
+
+	sa := loadSquare(a, "Solve")
+	sb := loadRHS(sa, b, "Solve")
+	n := sa.n
+	piv := make([]int, n, n)
+	var err error
+	for k, m := range sa.mats {
+		if _, ok := luFactor(m, piv, n); !ok {
+			err = ErrSingular
+			continue
+		}
+		luSolve(m, piv, sb.mats[k], n, sb.n)
+	}
+	return store(out, b.Shape, sb.mats, "Solve"), err
+}
+
+// Inv computes the inverse of square matrices.
+//
+// If out is nil a new array is created. Out may be in.
+// Returns ErrSingular if a matrix is singular.
+// Will panic if the matrices are not square or if the shape of out doesn't match.
+func Inv[F narray.Float](out, in *narray.NArray[F]) (*narray.NArray[F], error) {
+
+	s := loadSquare(in, "Inv")
+	n := s.n
+	piv := make([]int, n, n)
+	inv := make([][]float64, len(s.mats), len(s.mats))
+	var err error
+	for k, m := range s.mats {
+		inv[k] = make([]float64, n*n, n*n)
+		if _, ok := luFactor(m, piv, n); !ok {
+			err = ErrSingular
+			continue
+		}
+		for i := 0; i < n; i++ {
+			inv[k][i*n+i] = 1
+		}
+		luSolve(m, piv, inv[k], n, n)
+	}
+	return store(out, s.shape(), inv, "Inv"), err
+}
+
+// Det returns the determinants of square matrices. The shape of the result
+// is the shape of the leading axes of in, an narray of rank zero for a matrix:
+//
+//	d := linalg.Det(a).At()
+//
+// See LogDet to avoid overflow with large matrices.
+// Will panic if the matrices are not square.
+func Det[F narray.Float](in *narray.NArray[F]) *narray.NArray[F] {
+
+	s := loadSquare(in, "Det")
+	piv := make([]int, s.n, s.n)
+	det := make([]float64, len(s.mats), len(s.mats))
+	for k, m := range s.mats {
+		sign, _ := luFactor(m, piv, s.n)
+		det[k] = sign
+		for i := 0; i < s.n; i++ {
+			det[k] *= m[i*s.n+i]
+		}
+	}
+	return store[F](nil, s.batch, scalars(det), "Det")
+}
+
+// LogDet returns the natural logarithm of the absolute value of the
+// determinants of square matrices and their signs, so that
+//
+//	det = sign * exp(logdet)
+//
+// The sign is 1 or -1, or 0 for a singular matrix, whose logdet is -Inf.
+// The shape of the results is the shape of the leading axes of in.
+// Will panic if the matrices are not square.
+func LogDet[F narray.Float](in *narray.NArray[F]) (logdet, sign *narray.NArray[F]) {
+
+	s := loadSquare(in, "LogDet")
+	piv := make([]int, s.n, s.n)
+	ld := make([]float64, len(s.mats), len(s.mats))
+	sg := make([]float64, len(s.mats), len(s.mats))
+	for k, m := range s.mats {
+		sg[k], _ = luFactor(m, piv, s.n)
+		for i := 0; i < s.n; i++ {
+			d := m[i*s.n+i]
+			if d < 0 {
+				sg[k] = -sg[k]
+			}
+			ld[k] += math.Log(math.Abs(d))
+		}
+		if math.IsInf(ld[k], -1) {
+			sg[k] = 0
+		}
+	}
+	return store[F](nil, s.batch, scalars(ld), "LogDet"), store[F](nil, s.batch, scalars(sg), "LogDet")
+}
+
+// luFactor computes the LU factorization of the n x n matrix a in place and
+// the pivots. Returns the sign of the permutation and false if a is singular,
+// that is if a pivot is not larger than pivotTol of a.
+func luFactor(a []float64, piv []int, n int) (sign float64, ok bool) {
+
+	sign, ok = 1, true
+	tol := pivotTol(a, n, func(i, j int) bool { return true })
+	for i := range piv {
+		piv[i] = i
+	}
+	for j := 0; j < n; j++ {
+		// Pivot on the largest value in column j.
+		p := j
+		for i := j + 1; i < n; i++ {
+			if math.Abs(a[i*n+j]) > math.Abs(a[p*n+j]) {
+				p = i
+			}
+		}
+		if p != j {
+			for c := 0; c < n; c++ {
+				a[p*n+c], a[j*n+c] = a[j*n+c], a[p*n+c]
+			}
+			piv[p], piv[j] = piv[j], piv[p]
+			sign = -sign
+		}
+		d := a[j*n+j]
+		if math.Abs(d) <= tol {
+			ok = false
+		}
+		if d == 0 {
+			continue
+		}
+		for i := j + 1; i < n; i++ {
+			l := a[i*n+j] / d
+			a[i*n+j] = l
+			if l == 0 {
+				continue
+			}
+			ri, rj := a[i*n+j+1:i*n+n], a[j*n+j+1:j*n+n]
+			for c, v := range rj {
+				ri[c] -= l * v
+			}
+		}
+	}
+	return
+}
+
+// luSolve solves a * x = b in place for the n x k matrix b, given the
+// factorization of a computed by luFactor.
+func luSolve(lu []float64, piv []int, b []float64, n, k int) {
+
+	x := make([]float64, n*k, n*k)
+	for i, p := range piv {
+		copy(x[i*k:i*k+k], b[p*k:p*k+k])
+	}
+	solveLower(lu, x, n, k, true)
+	solveUpper(lu, x, n, k, false)
+	copy(b, x)
+}
+
+// solveLower solves l * x = b in place for the n x k matrix b, where l is
+// the lower triangle of the n x n matrix t. If unit is true, the diagonal
+// of l is one. Returns false if a value on the diagonal is not larger than
+// pivotTol of l.
+func solveLower(t, b []float64, n, k int, unit bool) bool {
+
+	tol := pivotTol(t, n, func(i, j int) bool { return j <= i })
+	for i := 0; i < n; i++ {
+		bi := b[i*k : i*k+k]
+		for j := 0; j < i; j++ {
+			v := t[i*n+j]
+			if v == 0 {
+				continue
+			}
+			for c, w := range b[j*k : j*k+k] {
+				bi[c] -= v * w
+			}
+		}
+		if !unit {
+			d := t[i*n+i]
+			if math.Abs(d) <= tol {
+				return false
+			}
+			for c := range bi {
+				bi[c] /= d
+			}
+		}
+	}
+	return true
+}
+
+// solveUpper solves u * x = b in place for the n x k matrix b, where u is
+// the upper triangle of the n x n matrix t. If unit is true, the diagonal
+// of u is one. Returns false if a value on the diagonal is not larger than
+// pivotTol of u.
+func solveUpper(t, b []float64, n, k int, unit bool) bool {
+
+	tol := pivotTol(t, n, func(i, j int) bool { return j >= i })
+	for i := n - 1; i >= 0; i-- {
+		bi := b[i*k : i*k+k]
+		for j := i + 1; j < n; j++ {
+			v := t[i*n+j]
+			if v == 0 {
+				continue
+			}
+			for c, w := range b[j*k : j*k+k] {
+				bi[c] -= v * w
+			}
+		}
+		if !unit {
+			d := t[i*n+i]
+			if math.Abs(d) <= tol {
+				return false
+			}
+			for c := range bi {
+				bi[c] /= d
+			}
+		}
+	}
+	return true
+}
+
+// eps is the float64 machine epsilon.
+const eps = 0x1p-52
+
+// pivotTol returns the tolerance below which a pivot of the n x n matrix a
+// is treated as zero, n * eps * max|a_ij| over the values where in(i, j)
+// is true. Pivots relative to the scale of the matrix catch the matrices
+// that are singular but whose rounded factors have no exact zero.
+func pivotTol(a []float64, n int, in func(i, j int) bool) float64 {
+
+	max := 0.0
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if in(i, j) {
+				max = math.Max(max, math.Abs(a[i*n+j]))
+			}
+		}
+	}
+	return float64(n) * eps * max
+}
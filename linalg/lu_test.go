@@ -0,0 +1,195 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/akualab/narray"
+)
+
+func panics(fn func()) (ok bool) {
+	defer func() {
+		ok = recover() != nil
+	}()
+	fn()
+	return
+}
+
+// permuteRows returns the rows of a in the order of piv.
+func permuteRows(a *narray.NArray[float64], piv []int) *narray.NArray[float64] {
+	n := a.Shape[1]
+	res := narray.New[float64](a.Shape...)
+	for i, p := range piv {
+		copy(res.Data[i*n:i*n+n], a.Data[p*n:p*n+n])
+	}
+	return res
+}
+
+func TestLU(t *testing.T) {
+
+	r := rand.New(rand.NewSource(11))
+	a := narray.Norm[float64](r, 0, 1, 5, 5)
+	lu, piv, err := LU(nil, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := narray.New[float64](5, 5)
+	u := narray.New[float64](5, 5)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			switch {
+			case i == j:
+				l.Set(1, i, j)
+				u.Set(lu.At(i, j), i, j)
+			case i > j:
+				l.Set(lu.At(i, j), i, j)
+			default:
+				u.Set(lu.At(i, j), i, j)
+			}
+		}
+	}
+	if !narray.EqualValues(narray.MatMul(nil, l, u), permuteRows(a, piv), 1e-9) {
+		t.Fatalf("L * U doesn't match the permuted matrix")
+	}
+
+	// A batch with a singular matrix.
+	b := narray.Norm[float64](r, 0, 1, 2, 3, 3)
+	b.SubArray(1, -1, -1).SetValue(1)
+	_, piv, err = LU(nil, b)
+	if err != ErrSingular || len(piv) != 6 {
+		t.Fatalf("expected ErrSingular and 6 pivots, got %v and %v", err, piv)
+	}
+	if !panics(func() { LU(nil, narray.New[float64](3, 4)) }) {
+		t.Fatalf("expected panic for a matrix that is not square")
+	}
+}
+
+func TestSolve(t *testing.T) {
+
+	r := rand.New(rand.NewSource(22))
+	a := narray.Norm[float32](r, 0, 1, 4, 4)
+	x := narray.Norm[float32](r, 0, 1, 4, 3)
+	b := narray.MatMul(nil, a, x)
+	res, err := Solve(nil, a, b)
+	if err != nil || !narray.EqualValues(res, x, 1e-4) {
+		t.Fatalf("expected %v, got %v, %v", x, res, err)
+	}
+
+	// A stack of systems with vectors, into a strided out.
+	as := narray.Norm[float64](r, 0, 1, 3, 5, 5)
+	xs := narray.Norm[float64](r, 0, 1, 3, 5)
+	bs := narray.New[float64](3, 5)
+	for k := 0; k < 3; k++ {
+		narray.MatMul(bs.SubArray(k, -1), as.SubArray(k, -1, -1), xs.SubArray(k, -1))
+	}
+	out := narray.New[float64](5, 3)
+	Solve(out.Transpose(), as, bs)
+	if !narray.EqualValues(out.Transpose().Copy(), xs, 1e-9) {
+		t.Fatalf("expected %v, got %v", xs, out.Transpose())
+	}
+
+	sing := narray.NewArray([]float64{1, 2, 2, 4}, 2, 2)
+	if _, err := Solve(nil, sing, narray.New[float64](2)); err != ErrSingular {
+		t.Fatalf("expected ErrSingular, got %v", err)
+	}
+	if !panics(func() { Solve(nil, as, narray.New[float64](3, 4)) }) {
+		t.Fatalf("expected panic for shape mismatch")
+	}
+}
+
+func TestInv(t *testing.T) {
+
+	r := rand.New(rand.NewSource(33))
+	a := narray.Norm[float64](r, 0, 1, 2, 6, 6)
+	inv, err := Inv(nil, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eye := narray.New[float64](6, 6)
+	for i := 0; i < 6; i++ {
+		eye.Set(1, i, i)
+	}
+	prod := narray.MatMul(nil, a, inv)
+	for k := 0; k < 2; k++ {
+		if !narray.EqualValues(prod.SubArray(k, -1, -1).Copy(), eye, 1e-9) {
+			t.Fatalf("a * inv(a) is not the identity: %v", prod.SubArray(k, -1, -1))
+		}
+	}
+	// In place.
+	Inv(a, a)
+	if !narray.EqualValues(a, inv, 0) {
+		t.Fatalf("expected %v, got %v", inv, a)
+	}
+	if _, err := Inv(nil, narray.New[float64](3, 3)); err != ErrSingular {
+		t.Fatalf("expected ErrSingular, got %v", err)
+	}
+
+	// Rank deficient, the rounded factors have no exact zero pivot.
+	rd := narray.NewArray([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, 3, 3)
+	if _, err := Inv(nil, rd); err != ErrSingular {
+		t.Fatalf("expected ErrSingular, got %v", err)
+	}
+	if _, err := Solve(nil, rd, narray.NewArray([]float64{1, 2, 3}, 3)); err != ErrSingular {
+		t.Fatalf("expected ErrSingular, got %v", err)
+	}
+	if _, err := Inv(nil, narray.Scale(nil, rd, 1e-20)); err != ErrSingular {
+		t.Fatalf("expected ErrSingular for a scaled matrix, got %v", err)
+	}
+	// Small values are not singular if the matrix is well conditioned.
+	small := narray.NewArray([]float64{1e-20, 0, 0, 2e-20}, 2, 2)
+	if _, err := Inv(nil, small); err != nil {
+		t.Fatalf("expected no error for a small matrix, got %v", err)
+	}
+}
+
+func TestDet(t *testing.T) {
+
+	a := narray.NewArray([]float64{
+		2, 1, 0,
+		1, 3, 1,
+		0, 1, 4,
+		0, 1, 0,
+		1, 0, 0,
+		0, 0, 1,
+		1, 2, 3,
+		2, 4, 6,
+		0, 1, 1,
+	}, 3, 3, 3)
+	det := Det(a)
+	exp := narray.NewArray([]float64{18, -1, 0}, 3)
+	if !narray.EqualValues(det, exp, 1e-12) {
+		t.Fatalf("expected %v, got %v", exp, det)
+	}
+	if d := Det(a.SubArray(0, -1, -1)); d.Rank != 0 || math.Abs(d.At()-18) > 1e-12 {
+		t.Fatalf("expected 18, got %v", d)
+	}
+
+	logdet, sign := LogDet(a)
+	if math.Abs(logdet.At(0)-math.Log(18)) > 1e-12 || sign.At(0) != 1 {
+		t.Fatalf("expected log(18) and sign 1, got %f and %f", logdet.At(0), sign.At(0))
+	}
+	if logdet.At(1) != 0 || sign.At(1) != -1 {
+		t.Fatalf("expected 0 and sign -1, got %f and %f", logdet.At(1), sign.At(1))
+	}
+	if !math.IsInf(logdet.At(2), -1) || sign.At(2) != 0 {
+		t.Fatalf("expected -Inf and sign 0, got %f and %f", logdet.At(2), sign.At(2))
+	}
+
+	// Large matrices overflow the determinant but not its logarithm.
+	big := narray.New[float64](400, 400)
+	for i := 0; i < 400; i++ {
+		big.Set(10, i, i)
+	}
+	if !math.IsInf(Det(big).At(), 1) {
+		t.Fatalf("expected overflow")
+	}
+	if ld, _ := LogDet(big); math.Abs(ld.At()-400*math.Log(10)) > 1e-9 {
+		t.Fatalf("expected %f, got %f", 400*math.Log(10), ld.At())
+	}
+}
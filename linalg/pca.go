@@ -0,0 +1,127 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"fmt"
+	"math"
+	"unsafe"
+
+	"github.com/akualab/narray"
+)
+
+// PCA is a principal component analysis computed by FitPCA.
+type PCA[F narray.Float] struct {
+	// Mean is the mean of the samples.
+	Mean *narray.NArray[F]
+	// Components holds the principal directions in its rows, k x dims,
+	// in order of decreasing variance.
+	Components *narray.NArray[F]
+	// Variance holds the variance of the samples along each component.
+	Variance *narray.NArray[F]
+	// Whiten scales the projections to unit variance.
+	Whiten bool
+}
+
+// FitPCA computes the first k principal components of the rows of x, a
+// samples x dims narray. If k < 1 all min(samples, dims) components are kept.
+// The components are the right singular vectors of the centered samples and
+// the sign of each component is set so that its largest value is positive.
+// If whiten is true, Transform scales the projections to unit variance.
+//
+//	pca, err := linalg.FitPCA(x, 10, true)
+//	y := pca.Transform(nil, x) // samples x 10
+//
+// Returns ErrSingular if whiten is true and a component has negligible
+// variance, a singular value s_j <= max(samples, dims) * eps * s_0 where eps
+// is the machine epsilon of F, or ErrNoConvergence if the SVD doesn't converge.
+// Will panic if x is not a matrix, if it has less than two samples or
+// if k is larger than min(samples, dims).
+func FitPCA[F narray.Float](x *narray.NArray[F], k int, whiten bool) (*PCA[F], error) {
+
+	if x.Rank != 2 || x.Shape[0] < 2 {
+		panic(&narray.ShapeError{Op: "FitPCA", Msg: "samples must be a matrix with two or more rows", Shapes: [][]int{x.Shape}})
+	}
+	n, d := x.Shape[0], x.Shape[1]
+	kmax := n
+	if d < kmax {
+		kmax = d
+	}
+	if k > kmax {
+		panic(fmt.Sprintf("linalg: can't compute [%d] components of samples with shape %v", k, x.Shape))
+	}
+	if k < 1 {
+		k = kmax
+	}
+
+	mean := narray.MeanAxis(nil, x, false, 0)
+	_, s, vt, err := SVD(nil, nil, nil, narray.Sub(nil, x, mean), false)
+	if err != nil {
+		return nil, err
+	}
+	p := &PCA[F]{
+		Mean:       mean,
+		Components: narray.NewArray(vt.Data[:k*d], k, d),
+		Variance:   narray.New[F](k),
+		Whiten:     whiten,
+	}
+	tol := float64(max(n, d)) * epsOf[F]() * float64(s.Data[0])
+	for j := 0; j < k; j++ {
+		sj := float64(s.Data[j])
+		p.Variance.Data[j] = F(sj * sj / float64(n-1))
+		if whiten && sj <= tol {
+			return nil, ErrSingular
+		}
+		row := p.Components.Data[j*d : j*d+d]
+		big := 0
+		for i, v := range row {
+			if math.Abs(float64(v)) > math.Abs(float64(row[big])) {
+				big = i
+			}
+		}
+		if row[big] < 0 {
+			for i := range row {
+				row[i] = -row[i]
+			}
+		}
+	}
+	return p, nil
+}
+
+// Transform projects the samples x on the principal components. Each row of
+// x, samples x dims, or x, of size dims, is projected to k values.
+// If out is nil a new array is created.
+// Will panic if the shapes don't match.
+func (p *PCA[F]) Transform(out, x *narray.NArray[F]) *narray.NArray[F] {
+
+	out = narray.MatMul(out, narray.Sub(nil, x, p.Mean), p.Components.Transpose())
+	if p.Whiten {
+		narray.Div(out, out, narray.Sqrt(nil, p.Variance))
+	}
+	return out
+}
+
+// InverseTransform maps projections y back to the space of the samples.
+// It is the inverse of Transform when all the components are kept.
+// If out is nil a new array is created.
+// Will panic if the shapes don't match.
+func (p *PCA[F]) InverseTransform(out, y *narray.NArray[F]) *narray.NArray[F] {
+
+	if p.Whiten {
+		y = narray.Mul(nil, y, narray.Sqrt(nil, p.Variance))
+	}
+	out = narray.MatMul(out, y, p.Components)
+	return narray.Add(out, out, p.Mean)
+}
+
+// epsOf returns the machine epsilon of F.
+func epsOf[F narray.Float]() float64 {
+
+	if unsafe.Sizeof(F(0)) == 4 {
+		return 0x1p-23
+	}
+	return eps
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/akualab/narray"
+)
+
+func TestPCA(t *testing.T) {
+
+	// Samples with a large variance along (1, 1, 0) and a small
+	// variance along (1, -1, 0), centered at (5, 5, 5).
+	r := rand.New(rand.NewSource(111))
+	n := 2000
+	x := narray.New[float64](n, 3)
+	for i := 0; i < n; i++ {
+		a, b := 3*r.NormFloat64(), 0.5*r.NormFloat64()
+		x.Set(5+a+b, i, 0)
+		x.Set(5+a-b, i, 1)
+		x.Set(5, i, 2)
+	}
+	pca, err := FitPCA(x, 2, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := 1 / math.Sqrt(2)
+	exp := narray.NewArray([]float64{c, c, 0, c, -c, 0}, 2, 3)
+	if !narray.EqualValues(pca.Components, exp, 1e-2) {
+		t.Fatalf("expected components %v, got %v", exp, pca.Components)
+	}
+	if math.Abs(pca.Variance.At(0)-18) > 1.5 || math.Abs(pca.Variance.At(1)-0.5) > 0.1 {
+		t.Fatalf("unexpected variance %v", pca.Variance)
+	}
+	if !narray.EqualValues(pca.Mean, narray.NewArray([]float64{5, 5, 5}, 3), 0.2) {
+		t.Fatalf("unexpected mean %v", pca.Mean)
+	}
+
+	y := pca.Transform(nil, x)
+	if y.Shape[0] != n || y.Shape[1] != 2 {
+		t.Fatalf("expected shape [%d 2], got %v", n, y.Shape)
+	}
+	// The projections are uncorrelated with the variance of the components.
+	cov := narray.Scale(nil, narray.MatMul(nil, y.Transpose(), y), 1/float64(n-1))
+	if !narray.EqualValues(cov, diag(pca.Variance), 1e-9) {
+		t.Fatalf("unexpected covariance of projections %v", cov)
+	}
+	if !narray.EqualValues(pca.InverseTransform(nil, y), x, 1e-9) {
+		t.Fatalf("inverse transform doesn't match")
+	}
+	// A single sample.
+	if !narray.EqualValues(pca.Transform(nil, x.SubArray(3, -1)), y.SubArray(3, -1).Copy(), 1e-12) {
+		t.Fatalf("unexpected projection of a vector")
+	}
+
+	// Whitening.
+	w, err := FitPCA(x, 0, false)
+	if err != nil || w.Components.Shape[0] != 3 {
+		t.Fatalf("expected 3 components, got %v, %v", w.Components.Shape, err)
+	}
+	if _, err := FitPCA(x, 0, true); err != ErrSingular {
+		t.Fatalf("expected ErrSingular for zero variance, got %v", err)
+	}
+	wp, err := FitPCA(x, 2, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	yw := wp.Transform(nil, x)
+	cov = narray.Scale(nil, narray.MatMul(nil, yw.Transpose(), yw), 1/float64(n-1))
+	if !narray.EqualValues(cov, eye(2), 1e-9) {
+		t.Fatalf("whitened projections don't have unit covariance %v", cov)
+	}
+	if !narray.EqualValues(wp.InverseTransform(nil, yw), x, 1e-9) {
+		t.Fatalf("inverse transform doesn't match")
+	}
+
+	if !panics(func() { FitPCA(x, 4, false) }) {
+		t.Fatalf("expected panic for too many components")
+	}
+
+	// A third column that is a combination of the others has a rounded,
+	// not exactly zero, singular value.
+	xd := x.Copy()
+	x32 := narray.New[float32](n, 3)
+	for i := 0; i < n; i++ {
+		v := 0.3*x.At(i, 0) + 0.7*x.At(i, 1)
+		xd.Set(v, i, 2)
+		x32.Set(float32(x.At(i, 0)), i, 0)
+		x32.Set(float32(x.At(i, 1)), i, 1)
+		x32.Set(float32(v), i, 2)
+	}
+	if _, err := FitPCA(xd, 0, true); err != ErrSingular {
+		t.Fatalf("expected ErrSingular for negligible variance, got %v", err)
+	}
+	if _, err := FitPCA(x32, 0, true); err != ErrSingular {
+		t.Fatalf("expected ErrSingular for negligible float32 variance, got %v", err)
+	}
+	if _, err := FitPCA(xd, 2, true); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math"
+
+	"github.com/akualab/narray"
+)
+
+// QR computes the reduced QR factorization of m x n matrices.
+//
+//	in = Q * R
+//
+// With k = min(m, n), Q is m x k with orthonormal columns and R is k x n
+// upper triangular. The factorization uses Householder reflections.
+//
+// If q or r are nil new arrays are created.
+// Will panic if the rank of in is less than two or if the shapes of
+// q or r don't match.
+func QR[F narray.Float](q, r, in *narray.NArray[F]) (*narray.NArray[F], *narray.NArray[F]) {
+
+	s := load(in, "QR")
+	m, n := s.m, s.n
+	k := m
+	if n < k {
+		k = n
+	}
+	qs := make([][]float64, len(s.mats), len(s.mats))
+	rs := make([][]float64, len(s.mats), len(s.mats))
+	for b, a := range s.mats {
+		qs[b], rs[b] = householder(a, m, n, k)
+	}
+	q = store(q, append(append([]int{}, s.batch...), m, k), qs, "QR")
+	r = store(r, append(append([]int{}, s.batch...), k, n), rs, "QR")
+	return q, r
+}
+
+// householder computes the reduced QR factorization of the m x n matrix a,
+// which is overwritten, and returns Q and R.
+func householder(a []float64, m, n, k int) (q, r []float64) {
+
+	// Reflection j is I - 2 * v * v' where v, of unit norm, is zero
+	// before row j. It zeroes column j of a below the diagonal.
+	vs := make([][]float64, k, k)
+	for j := 0; j < k; j++ {
+		v := make([]float64, m-j, m-j)
+		norm := 0.0
+		for i := range v {
+			v[i] = a[(i+j)*n+j]
+			norm += v[i] * v[i]
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			continue
+		}
+		// Choose the sign that avoids cancellation.
+		if v[0] > 0 {
+			norm = -norm
+		}
+		v[0] -= norm
+		vn := 0.0
+		for _, x := range v {
+			vn += x * x
+		}
+		vn = math.Sqrt(vn)
+		for i := range v {
+			v[i] /= vn
+		}
+		reflect(a, v, j, n)
+		vs[j] = v
+	}
+
+	r = make([]float64, k*n, k*n)
+	for i := 0; i < k; i++ {
+		copy(r[i*n+i:i*n+n], a[i*n+i:i*n+n])
+	}
+	// Q is the product of the reflections applied to the first k columns
+	// of the identity.
+	q = make([]float64, m*k, m*k)
+	for i := 0; i < k; i++ {
+		q[i*k+i] = 1
+	}
+	for j := k - 1; j >= 0; j-- {
+		if vs[j] != nil {
+			reflect(q, vs[j], j, k)
+		}
+	}
+	return q, r
+}
+
+// reflect applies the reflection I - 2 * v * v' to the rows from j of the
+// matrix a with n columns, where v has a value for each of those rows.
+func reflect(a, v []float64, j, n int) {
+
+	for c := 0; c < n; c++ {
+		dot := 0.0
+		for i, x := range v {
+			dot += x * a[(i+j)*n+c]
+		}
+		if dot == 0 {
+			continue
+		}
+		dot *= 2
+		for i, x := range v {
+			a[(i+j)*n+c] -= dot * x
+		}
+	}
+}
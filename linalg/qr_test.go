@@ -0,0 +1,61 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linalg
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/akualab/narray"
+)
+
+func TestQR(t *testing.T) {
+
+	r := rand.New(rand.NewSource(77))
+	for _, s := range [][2]int{[2]int{5, 3}, [2]int{3, 5}, [2]int{4, 4}} {
+		m, n := s[0], s[1]
+		k := m
+		if n < k {
+			k = n
+		}
+		a := narray.Norm[float64](r, 0, 1, 2, m, n)
+		q, rr := QR(nil, nil, a)
+		if !narray.EqualShape(q, narray.New[float64](2, m, k)) || !narray.EqualShape(rr, narray.New[float64](2, k, n)) {
+			t.Fatalf("%v: unexpected shapes %v and %v", s, q.Shape, rr.Shape)
+		}
+		if !narray.EqualValues(narray.MatMul(nil, q, rr), a, 1e-9) {
+			t.Fatalf("%v: Q * R doesn't match", s)
+		}
+		eye := narray.New[float64](k, k)
+		for i := 0; i < k; i++ {
+			eye.Set(1, i, i)
+		}
+		for b := 0; b < 2; b++ {
+			qb := q.SubArray(b, -1, -1)
+			if !narray.EqualValues(narray.MatMul(nil, qb.Transpose(), qb), eye, 1e-9) {
+				t.Fatalf("%v: columns of Q are not orthonormal", s)
+			}
+			for i := 0; i < k; i++ {
+				for j := 0; j < i; j++ {
+					if rr.At(b, i, j) != 0 {
+						t.Fatalf("%v: expected zero below the diagonal of R", s)
+					}
+				}
+			}
+		}
+	}
+
+	// A rank deficient matrix.
+	a := narray.NewArray([]float64{1, 2, 0, 0, 3, 6}, 3, 2)
+	a.SubArray(-1, 0).SetValue(0)
+	q, rr := QR(nil, nil, a)
+	if !narray.EqualValues(narray.MatMul(nil, q, rr), a, 1e-12) {
+		t.Fatalf("Q * R doesn't match")
+	}
+	if !panics(func() { QR(nil, nil, narray.New[float64](3)) }) {
+		t.Fatalf("expected panic for a vector")
+	}
+}
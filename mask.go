@@ -0,0 +1,327 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import "fmt"
+
+// Mask is a dense array of booleans used to select narray elements.
+// Masks are created by comparing narrays and are always contiguous.
+type Mask struct {
+	// The rank of the mask.
+	Rank int `json:"rank"`
+	// The size of each dimension.
+	Shape []int `json:"shape"`
+	// The data is stored as a slice of bool values in row-major order.
+	Data []bool `json:"data"`
+	// Strides for each dimension.
+	Strides []int `json:"strides"`
+}
+
+// NewMask creates a new mask with all values set to false.
+func NewMask(shape ...int) *Mask {
+
+	strides, size := rowMajor(shape)
+	return &Mask{
+		Rank:    len(shape),
+		Shape:   shape,
+		Data:    make([]bool, size, size),
+		Strides: strides,
+	}
+}
+
+// At returns the mask value for indices.
+func (m *Mask) At(indices ...int) bool {
+	return m.Data[index(m.Shape, m.Strides, 0, indices)]
+}
+
+// Set mask value for indices.
+func (m *Mask) Set(v bool, indices ...int) {
+	m.Data[index(m.Shape, m.Strides, 0, indices)] = v
+}
+
+// Count returns the number of true values in the mask.
+func (m *Mask) Count() int {
+
+	n := 0
+	for _, v := range m.Data {
+		if v {
+			n++
+		}
+	}
+	return n
+}
+
+// Not returns a new mask with the values negated.
+func (m *Mask) Not() *Mask {
+
+	res := NewMask(m.Shape...)
+	for k, v := range m.Data {
+		res.Data[k] = !v
+	}
+	return res
+}
+
+// And returns a new mask that is true where both masks are true.
+// Will panic if the shapes don't match.
+func (m *Mask) And(o *Mask) *Mask {
+
+	res := m.combine(o)
+	for k, v := range o.Data {
+		res.Data[k] = res.Data[k] && v
+	}
+	return res
+}
+
+// Or returns a new mask that is true where either mask is true.
+// Will panic if the shapes don't match.
+func (m *Mask) Or(o *Mask) *Mask {
+
+	res := m.combine(o)
+	for k, v := range o.Data {
+		res.Data[k] = res.Data[k] || v
+	}
+	return res
+}
+
+// combine checks that shapes match and returns a copy of the mask.
+func (m *Mask) combine(o *Mask) *Mask {
+
+	if !equalShapes(m.Shape, o.Shape) {
+		panic(&ShapeError{Msg: "masks must have equal shape", Shapes: [][]int{m.Shape, o.Shape}})
+	}
+	res := NewMask(m.Shape...)
+	copy(res.Data, m.Data)
+	return res
+}
+
+// layout returns an narray without data that has the shape and strides of the mask.
+// It is used to compute mask indices with the narray machinery.
+func layout[T Elem](m *Mask) *NArray[T] {
+	return &NArray[T]{Rank: m.Rank, Shape: m.Shape, Strides: m.Strides}
+}
+
+// FromMask returns an narray with the shape of the mask that is
+// one where the mask is true and zero elsewhere.
+func FromMask[T Elem](m *Mask) *NArray[T] {
+
+	na := New[T](m.Shape...)
+	for k, v := range m.Data {
+		if v {
+			na.Data[k] = 1
+		}
+	}
+	return na
+}
+
+// ToMask returns a mask that is true for the non-zero elements of the narray.
+func ToMask[T Elem](in *NArray[T]) *Mask {
+	return Match(in, func(v T) bool { return v != 0 })
+}
+
+// PredicateFunc is a type for creating custom conditions.
+type PredicateFunc[T Elem] func(x T) bool
+
+// Match returns a mask that is true for the elements of
+// the narray for which fn returns true.
+//
+//	// Select values below a floor.
+//	m := Match(x, func(v T) bool { return v < floor })
+func Match[T Elem](in *NArray[T], fn PredicateFunc[T]) *Mask {
+
+	m := NewMask(in.Shape...)
+	for k, v := range in.Contiguous().data() {
+		m.Data[k] = fn(v)
+	}
+	return m
+}
+
+// Greater returns a mask that is true where a > b elementwise.
+// The narrays are broadcast, see BroadcastShapes.
+func Greater[T Real](a, b *NArray[T]) *Mask {
+	return compare(a, b, func(x, y T) bool { return x > y })
+}
+
+// GreaterEqual returns a mask that is true where a >= b elementwise.
+// The narrays are broadcast, see BroadcastShapes.
+func GreaterEqual[T Real](a, b *NArray[T]) *Mask {
+	return compare(a, b, func(x, y T) bool { return x >= y })
+}
+
+// Less returns a mask that is true where a < b elementwise.
+// The narrays are broadcast, see BroadcastShapes.
+func Less[T Real](a, b *NArray[T]) *Mask {
+	return compare(a, b, func(x, y T) bool { return x < y })
+}
+
+// LessEqual returns a mask that is true where a <= b elementwise.
+// The narrays are broadcast, see BroadcastShapes.
+func LessEqual[T Real](a, b *NArray[T]) *Mask {
+	return compare(a, b, func(x, y T) bool { return x <= y })
+}
+
+// Equal returns a mask that is true where a == b elementwise.
+// The narrays are broadcast, see BroadcastShapes.
+func Equal[T Elem](a, b *NArray[T]) *Mask {
+	return compare(a, b, func(x, y T) bool { return x == y })
+}
+
+// NotEqual returns a mask that is true where a != b elementwise.
+// The narrays are broadcast, see BroadcastShapes.
+func NotEqual[T Elem](a, b *NArray[T]) *Mask {
+	return compare(a, b, func(x, y T) bool { return x != y })
+}
+
+// compare broadcasts a and b and applies cmp elementwise.
+func compare[T Elem](a, b *NArray[T], cmp func(x, y T) bool) *Mask {
+
+	shape, err := BroadcastShapes(a.Shape, b.Shape)
+	if err != nil {
+		panic(err)
+	}
+	m := NewMask(shape...)
+	ad := a.Broadcast(shape...).Contiguous().data()
+	bd := b.Broadcast(shape...).Contiguous().data()
+	for k := range m.Data {
+		m.Data[k] = cmp(ad[k], bd[k])
+	}
+	return m
+}
+
+// Where selects elements from a where cond is true and from b otherwise.
+//
+//	out[i,j,k,...] = cond[i,j,k,...] ? a[i,j,k,...] : b[i,j,k,...]
+//
+// The mask and the narrays are broadcast, see BroadcastShapes.
+// If out is nil a new array is created.
+func Where[T Elem](out *NArray[T], cond *Mask, a, b *NArray[T]) *NArray[T] {
+
+	shape, err := BroadcastShapes(cond.Shape, a.Shape, b.Shape)
+	if err != nil {
+		panic(err)
+	}
+	if out == nil {
+		out = New[T](shape...)
+	} else if !EqualShape(out, &NArray[T]{Shape: shape}) {
+		panic(&ShapeError{Op: "Where", Msg: "out shape doesn't match broadcast shape", Shapes: [][]int{out.Shape, shape}})
+	}
+	res := make([]T, out.Size(), out.Size())
+	ad := a.Broadcast(shape...).Contiguous().data()
+	bd := b.Broadcast(shape...).Contiguous().data()
+	layout[T](cond).Broadcast(shape...).walk(func(k, idx int) {
+		if cond.Data[idx] {
+			res[k] = ad[k]
+		} else {
+			res[k] = bd[k]
+		}
+	})
+	out.scatter(res)
+	return out
+}
+
+// MaskedSelect returns a vector with the elements of the narray
+// for which the mask is true, in row-major order.
+// Will panic if the shapes of the narray and the mask don't match.
+func MaskedSelect[T Elem](in *NArray[T], mask *Mask) *NArray[T] {
+
+	if !EqualShape(in, layout[T](mask)) {
+		panic(shapeError("MaskedSelect", "narray and mask must have equal shape", in, layout[T](mask)))
+	}
+	res := make([]T, 0, mask.Count())
+	for k, v := range in.Contiguous().data() {
+		if mask.Data[k] {
+			res = append(res, v)
+		}
+	}
+	return NewArray(res, len(res))
+}
+
+// MaskedFill sets the elements for which the mask is true to v.
+// The other elements are copied from in. Use out == in to fill in place.
+//
+//	// Floor values in place.
+//	MaskedFill(x, x, Match(x, func(v T) bool { return v < floor }), floor)
+//
+// If out is nil a new array is created.
+// Will panic if the shapes of the narrays and the mask don't match.
+func MaskedFill[T Elem](out, in *NArray[T], mask *Mask, v T) *NArray[T] {
+
+	if out == nil {
+		out = New[T](in.Shape...)
+	}
+	if !EqualShape(out, in, layout[T](mask)) {
+		panic(shapeError("MaskedFill", "narrays and mask must have equal shape", out, in, layout[T](mask)))
+	}
+	unaryOp(func(o, x []T) {
+		for k := range x {
+			if mask.Data[k] {
+				o[k] = v
+			} else {
+				o[k] = x[k]
+			}
+		}
+	}, out, in)
+	return out
+}
+
+// Take returns a new narray with the elements at the given
+// indices along an axis. Indices may be repeated and in any order.
+//
+//	// Select rows 3, 0 and 3 of a matrix.
+//	y := x.Take(0, 3, 0, 3)
+//
+// Will panic if the axis or an index is out of range.
+func (na *NArray[T]) Take(axis int, indices ...int) *NArray[T] {
+
+	if axis < 0 || axis >= na.Rank {
+		panic(shapeError("Take", fmt.Sprintf("axis [%d] out of range", axis), na))
+	}
+	shape := make([]int, na.Rank, na.Rank)
+	copy(shape, na.Shape)
+	shape[axis] = len(indices)
+	out := New[T](shape...)
+	for k, i := range indices {
+		unaryOp(copySlice, axisView(out, axis, k), axisView(na, axis, i))
+	}
+	return out
+}
+
+// Put sets the elements at the given indices along an axis to the values
+// in values. It is the inverse of Take: the shape of values must match the
+// shape returned by Take for the same indices, or be broadcastable to it.
+// If an index is repeated, the last value is used.
+//
+// Will panic if the axis or an index is out of range or if shapes don't match.
+func (na *NArray[T]) Put(values *NArray[T], axis int, indices ...int) {
+
+	if axis < 0 || axis >= na.Rank {
+		panic(shapeError("Put", fmt.Sprintf("axis [%d] out of range", axis), na))
+	}
+	shape := make([]int, na.Rank, na.Rank)
+	copy(shape, na.Shape)
+	shape[axis] = len(indices)
+	values = values.Broadcast(shape...)
+	for k, i := range indices {
+		unaryOp(copySlice, axisView(na, axis, i), axisView(values, axis, k))
+	}
+}
+
+// axisView returns the view of the narray at index i along axis.
+func axisView[T Elem](na *NArray[T], axis, i int) *NArray[T] {
+
+	if i < 0 || i >= na.Shape[axis] {
+		panic(indexError([]int{i}, na.Shape[axis:axis+1]))
+	}
+	specs := make([]SliceSpec, axis+1, axis+1)
+	for k := 0; k < axis; k++ {
+		specs[k] = All
+	}
+	specs[axis] = Pick(i)
+	return na.Slice(specs...)
+}
+
+func copySlice[T Elem](out, a []T) {
+	copy(out, a)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package {{.Package}}
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+
+	seven := NewArray([]{{.Format}}{7}, 1)
+	gt := Greater(x, seven)
+	if gt.Count() != 7 {
+		t.Fatalf("expected 7 values > 7, got %d", gt.Count())
+	}
+	if gt.At(1, 2) || !gt.At(1, 3) {
+		t.Fatalf("unexpected mask values")
+	}
+	if GreaterEqual(x, seven).Count() != 8 || Less(x, seven).Count() != 7 ||
+		LessEqual(x, seven).Count() != 8 || Equal(x, seven).Count() != 1 ||
+		NotEqual(x, seven).Count() != 14 {
+		t.Fatalf("unexpected counts")
+	}
+	if gt.Not().Count() != 8 || gt.And(Less(x, y)).Count() != 7 || gt.Or(Equal(x, seven)).Count() != 8 {
+		t.Fatalf("unexpected counts for logical ops")
+	}
+
+	m := Match(x, func(v {{.Format}}) bool { return int(v)%2 == 0 })
+	if m.Count() != 8 {
+		t.Fatalf("expected 8 even values, got %d", m.Count())
+	}
+}
+
+func TestWhere(t *testing.T) {
+
+	zero := New()
+	floor := NewArray([]{{.Format}}{5}, 1)
+	out := Where(nil, Less(x, floor), zero, x)
+	for k, v := range out.Data {
+		if x.Data[k] < 5 && v != 0 || x.Data[k] >= 5 && v != x.Data[k] {
+			t.Fatalf("unexpected value %f for %f", v, x.Data[k])
+		}
+	}
+
+	// Broadcast a column mask.
+	col := NewMask(3, 1)
+	col.Set(true, 1, 0)
+	out = Where(nil, col, x, y)
+	if out.At(1, 3) != x.At(1, 3) || out.At(2, 3) != y.At(2, 3) {
+		t.Fatalf("where with broadcast mask failed")
+	}
+}
+
+func TestMaskedSelectFill(t *testing.T) {
+
+	m := Match(x, func(v {{.Format}}) bool { return v > 11 })
+	sel := MaskedSelect(x, m)
+	if sel.Rank != 1 || sel.Shape[0] != 3 || sel.At(0) != 12 || sel.At(2) != 14 {
+		t.Fatalf("unexpected selection %v", sel)
+	}
+
+	xx := x.Copy()
+	MaskedFill(xx, xx, m, -1)
+	if xx.Max() != 11 || MaskedSelect(xx, m).Sum() != -3 {
+		t.Fatalf("unexpected fill %v", xx)
+	}
+
+	// Fill a view.
+	na := na234.Copy()
+	v := na.SubArray(-1, 1, -1)
+	MaskedFill(v, v, Match(v, func({{.Format}}) bool { return true }), 0)
+	if na.SubArray(-1, 1, -1).Sum() != 0 || na.At(1, 2, 3) != na234.At(1, 2, 3) {
+		t.Fatalf("fill of view failed")
+	}
+}
+
+func TestTakePut(t *testing.T) {
+
+	rows := x.Take(0, 2, 0, 2)
+	if rows.Shape[0] != 3 || rows.Shape[1] != 5 {
+		t.Fatalf("expected shape [3 5], got %v", rows.Shape)
+	}
+	if rows.At(0, 4) != x.At(2, 4) || rows.At(1, 4) != x.At(0, 4) {
+		t.Fatalf("take failed: %v", rows)
+	}
+	cols := na234.Take(2, 3, 1)
+	if cols.Shape[2] != 2 || cols.At(1, 2, 0) != na234.At(1, 2, 3) {
+		t.Fatalf("take failed: %v", cols)
+	}
+
+	na := na234.Copy()
+	na.Put(cols, 2, 0, 2)
+	if na.At(1, 2, 0) != na234.At(1, 2, 3) || na.At(1, 2, 2) != na234.At(1, 2, 1) {
+		t.Fatalf("put failed: %v", na)
+	}
+	na.Put(New(), 0, 1)
+	if na.SubArray(1, -1, -1).Sum() != 0 {
+		t.Fatalf("put with broadcast failed: %v", na)
+	}
+
+	if !panics(func() { x.Take(0, 3) }) {
+		t.Errorf("did not panic with index out of range")
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import "fmt"
+
+// Block sizes of the matrix product kernel. A block of blockK rows
+// and blockN columns of the right operand stays in cache while it
+// is used for all the rows of the left operand.
+const (
+	blockK = 128
+	blockN = 256
+)
+
+// MatMul returns the matrix product of a and b.
+//
+//	out[..., i, j] = sum_k a[..., i, k] * b[..., k, j]
+//
+// Narrays of rank two are matrices. Narrays of rank three or more are
+// stacks of matrices in the last two axes and the leading axes are
+// broadcast, see BroadcastShapes. If a is a vector, it is a matrix
+// with one row, and if b is a vector, it is a matrix with one column;
+// the added axis is removed from the result. For example:
+//
+//	a          b          out
+//	-------------------------------
+//	5x3        3x4        5x4
+//	10x5x3     3x4        10x5x4
+//	5x3        3          5       matrix-vector
+//	3          3x4        4       vector-matrix
+//
+// Complex values are not conjugated.
+// If out is nil a new array is created.
+// Will panic if a or b are scalars, if the inner dimensions don't match,
+// if the leading axes can't be broadcast or if the shape of out doesn't match.
+func MatMul[T Elem](out, a, b *NArray[T]) *NArray[T] {
+
+	if a.Rank == 0 || b.Rank == 0 {
+		panic(shapeError("MatMul", "narrays must have rank one or more", a, b))
+	}
+	am, bm := a, b
+	if a.Rank == 1 {
+		am = a.Reshape(1, a.Shape[0])
+	}
+	if b.Rank == 1 {
+		bm = b.Reshape(b.Shape[0], 1)
+	}
+	ra, rb := am.Rank, bm.Rank
+	m, k := am.Shape[ra-2], am.Shape[ra-1]
+	n := bm.Shape[rb-1]
+	if bm.Shape[rb-2] != k {
+		panic(shapeError("MatMul", "inner dimensions must match", a, b))
+	}
+	batch, err := BroadcastShapes(am.Shape[:ra-2], bm.Shape[:rb-2])
+	if err != nil {
+		panic(err)
+	}
+
+	shape := append([]int{}, batch...)
+	if a.Rank > 1 {
+		shape = append(shape, m)
+	}
+	if b.Rank > 1 {
+		shape = append(shape, n)
+	}
+	if out == nil {
+		out = New[T](shape...)
+	} else if !EqualShape(out, &NArray[T]{Shape: shape}) {
+		panic(&ShapeError{Op: "MatMul", Msg: "out shape doesn't match product shape", Shapes: [][]int{out.Shape, shape}})
+	}
+
+	// Iterate over the matrices in the broadcast leading axes.
+	nb := len(batch)
+	ab := am.Broadcast(append(append([]int{}, batch...), m, k)...)
+	bb := bm.Broadcast(append(append([]int{}, batch...), k, n)...)
+	res := make([]T, out.Size(), out.Size())
+	matrix := func(na *NArray[T], offset int) []T {
+		v := &NArray[T]{Rank: 2, Shape: na.Shape[nb:], Strides: na.Strides[nb:], Data: na.Data, Offset: offset}
+		return v.Contiguous().data()
+	}
+	if nb == 0 {
+		gemm(res, matrix(ab, ab.Offset), matrix(bb, bb.Offset), m, k, n)
+	} else {
+		axes := make([]int, nb, nb)
+		for i := range axes {
+			axes[i] = i
+		}
+		ai, bi := ab.Iter(axes...), bb.Iter(axes...)
+		for c := 0; ai.Next() && bi.Next(); c += m * n {
+			gemm(res[c:c+m*n], matrix(ab, ai.Offset()), matrix(bb, bi.Offset()), m, k, n)
+		}
+	}
+	out.scatter(res)
+	return out
+}
+
+// TensorDot returns the sum of the products of a and b over the
+// axes axesA of a and axesB of b. Axis axesA[i] of a is contracted
+// with axis axesB[i] of b. The axes of the result are the remaining
+// axes of a followed by the remaining axes of b.
+//
+//	// Given a with shape 2x3x4 and b with shape 4x3x5,
+//	// out has shape 2x5 and out[i,j] = sum_{k,l} a[i,k,l] * b[l,k,j].
+//	out := TensorDot(nil, a, b, []int{1, 2}, []int{1, 0})
+//
+// Complex values are not conjugated.
+// If out is nil a new array is created.
+// Will panic if the axes are invalid, if the sizes of contracted axes
+// don't match or if the shape of out doesn't match.
+func TensorDot[T Elem](out, a, b *NArray[T], axesA, axesB []int) *NArray[T] {
+
+	if len(axesA) != len(axesB) {
+		panic(shapeError("TensorDot", fmt.Sprintf("expected the same number of axes, got %v and %v", axesA, axesB), a, b))
+	}
+	freeA := freeAxes(a, axesA)
+	freeB := freeAxes(b, axesB)
+	k := 1
+	for i, v := range axesA {
+		if a.Shape[v] != b.Shape[axesB[i]] {
+			panic(shapeError("TensorDot", "contracted axes must have equal size", a, b))
+		}
+		k *= a.Shape[v]
+	}
+	m, n := 1, 1
+	shape := []int{}
+	for _, v := range freeA {
+		m *= a.Shape[v]
+		shape = append(shape, a.Shape[v])
+	}
+	for _, v := range freeB {
+		n *= b.Shape[v]
+		shape = append(shape, b.Shape[v])
+	}
+	if out == nil {
+		out = New[T](shape...)
+	} else if !EqualShape(out, &NArray[T]{Shape: shape}) {
+		panic(&ShapeError{Op: "TensorDot", Msg: "out shape doesn't match product shape", Shapes: [][]int{out.Shape, shape}})
+	}
+
+	am := a.Permute(append(append([]int{}, freeA...), axesA...)...).Reshape(m, k)
+	bm := b.Permute(append(append([]int{}, axesB...), freeB...)...).Reshape(k, n)
+	res := make([]T, m*n, m*n)
+	gemm(res, am.Data, bm.Data, m, k, n)
+	out.scatter(res)
+	return out
+}
+
+// freeAxes returns the axes of the narray that are not in axes.
+// Will panic if axes has repeated values or values out of range.
+func freeAxes[T Elem](na *NArray[T], axes []int) []int {
+
+	used := make([]bool, na.Rank, na.Rank)
+	for _, v := range axes {
+		if v < 0 || v >= na.Rank || used[v] {
+			panic(shapeError("TensorDot", fmt.Sprintf("invalid axes %v", axes), na))
+		}
+		used[v] = true
+	}
+	free := []int{}
+	for k, u := range used {
+		if !u {
+			free = append(free, k)
+		}
+	}
+	return free
+}
+
+// gemm computes the matrix product c = a * b, where a is m x k, b is k x n and c
+// is m x n, all in row-major order. The values in c are overwritten.
+//
+// The rows of c accumulate scaled rows of b with addScaledSlice. The loops
+// are blocked so that a block of b is reused from cache for all the rows of a.
+// Matrix-vector products use dot products instead.
+func gemm[T Elem](c, a, b []T, m, k, n int) {
+
+	for i := range c {
+		c[i] = 0
+	}
+	if n == 1 && k > 0 {
+		buf := make([]T, k, k)
+		for i := 0; i < m; i++ {
+			mulSlice(buf, a[i*k:i*k+k], b)
+			c[i] = sliceSum(buf)
+		}
+		return
+	}
+	for j0 := 0; j0 < n; j0 += blockN {
+		j1 := j0 + blockN
+		if j1 > n {
+			j1 = n
+		}
+		for p0 := 0; p0 < k; p0 += blockK {
+			p1 := p0 + blockK
+			if p1 > k {
+				p1 = k
+			}
+			for i := 0; i < m; i++ {
+				ci := c[i*n+j0 : i*n+j1]
+				for p := p0; p < p1; p++ {
+					addScaledSlice(ci, b[p*n+j0:p*n+j1], a[i*k+p])
+				}
+			}
+		}
+	}
+}
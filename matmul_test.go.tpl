@@ -0,0 +1,303 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package {{.Package}}
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// naiveMatMul multiplies matrices using At.
+func naiveMatMul(a, b *NArray) *NArray {
+	m, k, n := a.Shape[0], a.Shape[1], b.Shape[1]
+	res := New(m, n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			var sum {{.Format}}
+			for p := 0; p < k; p++ {
+				sum += a.At(i, p) * b.At(p, j)
+			}
+			res.Set(sum, i, j)
+		}
+	}
+	return res
+}
+
+func TestMatMul(t *testing.T) {
+
+	r := rand.New(rand.NewSource(33))
+	// Sizes that cross the block boundaries of the kernel.
+	sizes := [][3]int{
+		[3]int{1, 1, 1}, [3]int{3, 5, 4}, [3]int{7, 300, 520}, [3]int{4, 0, 3},
+	}
+	for _, s := range sizes {
+		a := Norm(r, 0, 1, s[0], s[1])
+		b := Norm(r, 0, 1, s[1], s[2])
+		out := MatMul(nil, a, b)
+		if !EqualValues(out, naiveMatMul(a, b), 0.001) {
+			t.Fatalf("%v: unexpected product", s)
+		}
+	}
+
+	// Strided inputs and output.
+	a := Norm(r, 0, 1, 4, 6)
+	b := Norm(r, 0, 1, 6, 3)
+	exp := naiveMatMul(a, b)
+	out := New(3, 4)
+	MatMul(out.Transpose(), a.Transpose().Copy().Transpose(), b)
+	if !EqualValues(out.Transpose().Copy(), exp, 0.0001) {
+		t.Fatalf("expected %v, got %v", exp, out.Transpose())
+	}
+	// Output aliases an input.
+	sq := Norm(r, 0, 1, 4, 4)
+	exp = naiveMatMul(sq, sq)
+	MatMul(sq, sq, sq)
+	if !EqualValues(sq, exp, 0.0001) {
+		t.Fatalf("expected %v, got %v", exp, sq)
+	}
+
+	// Matrix-vector and vector-matrix products.
+	v := Norm(r, 0, 1, 6)
+	mv := MatMul(nil, a, v)
+	if mv.Rank != 1 || mv.Shape[0] != 4 {
+		t.Fatalf("expected shape [4], got %v", mv.Shape)
+	}
+	if !EqualValues(mv, naiveMatMul(a, v.Reshape(6, 1)).Reshape(4), 0.0001) {
+		t.Fatalf("unexpected matrix-vector product %v", mv)
+	}
+	vm := MatMul(nil, v, b)
+	if vm.Rank != 1 || !EqualValues(vm, naiveMatMul(v.Reshape(1, 6), b).Reshape(3), 0.0001) {
+		t.Fatalf("unexpected vector-matrix product %v", vm)
+	}
+	if d := MatMul(nil, v, v); d.Rank != 0 || !EqualValues(d.Reshape(1), NewArray([]{{.Format}}{Dot(v, v)}, 1), 0.0001) {
+		t.Fatalf("expected dot product %v, got %v", Dot(v, v), d)
+	}
+
+	if !panics(func() { MatMul(nil, a, a) }) {
+		t.Fatalf("expected panic for inner dimension mismatch")
+	}
+	if !panics(func() { MatMul(New(4, 4), a, b) }) {
+		t.Fatalf("expected panic for out shape mismatch")
+	}
+}
+
+func TestBatchedMatMul(t *testing.T) {
+
+	r := rand.New(rand.NewSource(44))
+	a := Norm(r, 0, 1, 2, 5, 4, 3)
+	b := Norm(r, 0, 1, 5, 3, 2)
+	out := MatMul(nil, a, b)
+	if out.Rank != 4 || out.Shape[0] != 2 || out.Shape[1] != 5 || out.Shape[2] != 4 || out.Shape[3] != 2 {
+		t.Fatalf("expected shape [2 5 4 2], got %v", out.Shape)
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 5; j++ {
+			exp := naiveMatMul(a.SubArray(i, j, -1, -1), b.SubArray(j, -1, -1))
+			if !EqualValues(out.SubArray(i, j, -1, -1).Copy(), exp, 0.0001) {
+				t.Fatalf("batch [%d %d]: expected %v, got %v", i, j, exp, out.SubArray(i, j, -1, -1))
+			}
+		}
+	}
+
+	// Broadcast a matrix against a stack.
+	m := Norm(r, 0, 1, 3, 4)
+	res := MatMul(nil, m, a)
+	for j := 0; j < 5; j++ {
+		exp := naiveMatMul(m, a.SubArray(1, j, -1, -1))
+		if !EqualValues(res.SubArray(1, j, -1, -1).Copy(), exp, 0.0001) {
+			t.Fatalf("batch [1 %d]: expected %v, got %v", j, exp, res.SubArray(1, j, -1, -1))
+		}
+	}
+	if !panics(func() { MatMul(nil, a, Norm(r, 0, 1, 4, 3, 2)) }) {
+		t.Fatalf("expected panic for batch mismatch")
+	}
+}
+
+func TestTensorDot(t *testing.T) {
+
+	r := rand.New(rand.NewSource(55))
+	a := Norm(r, 0, 1, 2, 3, 4)
+	b := Norm(r, 0, 1, 4, 3, 5)
+	out := TensorDot(nil, a, b, []int{1, 2}, []int{1, 0})
+	if out.Rank != 2 || out.Shape[0] != 2 || out.Shape[1] != 5 {
+		t.Fatalf("expected shape [2 5], got %v", out.Shape)
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 5; j++ {
+			var sum {{.Format}}
+			for k := 0; k < 3; k++ {
+				for l := 0; l < 4; l++ {
+					sum += a.At(i, k, l) * b.At(l, k, j)
+				}
+			}
+			if !EqualValues(NewArray([]{{.Format}}{out.At(i, j)}, 1), NewArray([]{{.Format}}{sum}, 1), 0.0001) {
+				t.Fatalf("[%d %d]: expected %f, got %f", i, j, sum, out.At(i, j))
+			}
+		}
+	}
+
+	// Outer product and full contraction.
+	outer := TensorDot(nil, a, b, nil, nil)
+	if outer.Rank != 6 || outer.At(1, 2, 3, 3, 2, 4) != a.At(1, 2, 3)*b.At(3, 2, 4) {
+		t.Fatalf("unexpected outer product with shape %v", outer.Shape)
+	}
+	full := TensorDot(nil, a, a, []int{0, 1, 2}, []int{0, 1, 2})
+	if full.Rank != 0 || !EqualValues(full.Reshape(1), NewArray([]{{.Format}}{Dot(a, a)}, 1), 0.0001) {
+		t.Fatalf("expected %f, got %v", Dot(a, a), full)
+	}
+
+	if !panics(func() { TensorDot(nil, a, b, []int{0}, []int{0}) }) {
+		t.Fatalf("expected panic for size mismatch")
+	}
+	if !panics(func() { TensorDot(nil, a, b, []int{2, 2}, []int{0, 1}) }) {
+		t.Fatalf("expected panic for repeated axes")
+	}
+}
+
+// naiveEinsum computes an explicit einsum spec by looping over all the
+// values of the labels with At.
+func naiveEinsum(spec string, ops ...*NArray) *NArray {
+
+	arrow := strings.Index(spec, "->")
+	inputs := strings.Split(spec[:arrow], ",")
+	output := spec[arrow+2:]
+	sizes := map[byte]int{}
+	var labels []byte
+	for k, in := range inputs {
+		for i := 0; i < len(in); i++ {
+			if _, ok := sizes[in[i]]; !ok {
+				labels = append(labels, in[i])
+			}
+			sizes[in[i]] = ops[k].Shape[i]
+		}
+	}
+	shape := make([]int, len(output), len(output))
+	for i := range shape {
+		shape[i] = sizes[output[i]]
+	}
+	res := New(shape...)
+	values := map[byte]int{}
+	indices := func(s string) []int {
+		idx := make([]int, len(s), len(s))
+		for i := range idx {
+			idx[i] = values[s[i]]
+		}
+		return idx
+	}
+	var loop func(n int)
+	loop = func(n int) {
+		if n == len(labels) {
+			var p {{.Format}} = 1
+			for k, in := range inputs {
+				p *= ops[k].At(indices(in)...)
+			}
+			idx := indices(output)
+			res.Set(res.At(idx...)+p, idx...)
+			return
+		}
+		for v := 0; v < sizes[labels[n]]; v++ {
+			values[labels[n]] = v
+			loop(n + 1)
+		}
+	}
+	loop(0)
+	return res
+}
+
+func TestEinsum(t *testing.T) {
+
+	r := rand.New(rand.NewSource(66))
+	a := Norm(r, 0, 1, 4, 3)
+	b := Norm(r, 0, 1, 3, 5)
+	sq := Norm(r, 0, 1, 4, 4)
+	x3 := Norm(r, 0, 1, 2, 4, 3)
+	y3 := Norm(r, 0, 1, 2, 3, 5)
+	v := Norm(r, 0, 1, 3)
+	tests := []struct {
+		spec string
+		ops  []*NArray
+	}{
+		{spec: "ij,jk->ik", ops: []*NArray{a, b}},
+		{spec: "bij,bjk->bik", ops: []*NArray{x3, y3}},
+		{spec: "ti,tj->ij", ops: []*NArray{a, a}},
+		{spec: "ij->ji", ops: []*NArray{a}},
+		{spec: "ii->i", ops: []*NArray{sq}},
+		{spec: "ii->", ops: []*NArray{sq}},
+		{spec: "ij->", ops: []*NArray{a}},
+		{spec: "ij->j", ops: []*NArray{a}},
+		{spec: "i,i->", ops: []*NArray{v, v}},
+		{spec: "i,j->ij", ops: []*NArray{v, v}},
+		{spec: "ij,jk,kl->il", ops: []*NArray{a, b, b.Transpose()}},
+		{spec: "bij,j->bi", ops: []*NArray{x3, v}},
+		{spec: "bij,bjk->kib", ops: []*NArray{x3, y3}},
+		{spec: "bij,bik->bjk", ops: []*NArray{x3, x3}},
+		{spec: "ij,ij,ij->i", ops: []*NArray{a, a, a}},
+	}
+	for _, tt := range tests {
+		out := Einsum(tt.spec, tt.ops...)
+		exp := naiveEinsum(tt.spec, tt.ops...)
+		if !EqualShape(out, exp) || !EqualValues(out, exp, 0.0001) {
+			t.Fatalf("%s: expected %v, got %v", tt.spec, exp, out)
+		}
+	}
+
+	// Implicit output and spaces.
+	if out := Einsum("ij, jk", a, b); !EqualValues(out, MatMul(nil, a, b), 0.0001) {
+		t.Fatalf("unexpected implicit matrix product %v", out)
+	}
+	if out := Einsum("ii", sq); out.Rank != 0 || !EqualValues(out, naiveEinsum("ii->", sq), 0.0001) {
+		t.Fatalf("unexpected trace %v", out)
+	}
+	// The result doesn't share data with the operands.
+	tr := Einsum("ij->ij", a)
+	tr.Set(100, 0, 0)
+	if a.At(0, 0) == 100 {
+		t.Fatalf("result shares data with operand")
+	}
+
+	if !panics(func() { Einsum("ij,jk->ik", a, a) }) {
+		t.Fatalf("expected panic for size mismatch")
+	}
+	if !panics(func() { Einsum("ijk,jk->i", a, b) }) {
+		t.Fatalf("expected panic for rank mismatch")
+	}
+	if !panics(func() { Einsum("ij,jk->ik", a) }) {
+		t.Fatalf("expected panic for number of operands")
+	}
+	if !panics(func() { Einsum("ij->iz", a) }) {
+		t.Fatalf("expected panic for unknown output label")
+	}
+	if !panics(func() { Einsum("i1->i", a) }) {
+		t.Fatalf("expected panic for invalid label")
+	}
+	_, err := EinsumE("ij,jk->ik", a, a)
+	if _, ok := err.(*ShapeError); !ok {
+		t.Fatalf("expected *ShapeError, got %v", err)
+	}
+	t.Log(err)
+
+	// Invalid specs are errors, not panics.
+	for _, tt := range []struct {
+		spec string
+		ops  []*NArray
+	}{
+		{"ij,jk->ik", []*NArray{a}},
+		{"ij->i", []*NArray{a, b}},
+		{"i1->i", []*NArray{a}},
+		{"ij->ii", []*NArray{a}},
+		{"ij->iz", []*NArray{a}},
+	} {
+		_, err := EinsumE(tt.spec, tt.ops...)
+		if _, ok := err.(*ShapeError); !ok {
+			t.Fatalf("%s: expected *ShapeError, got %v", tt.spec, err)
+		}
+	}
+	_, err = EinsumE("ij,jk->ik", a)
+	if !strings.Contains(err.Error(), "spec has [2] operands, got [1]") {
+		t.Fatalf("unexpected message %q", err)
+	}
+}
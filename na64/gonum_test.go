@@ -7,7 +7,12 @@
 
 package na64
 
-import "testing"
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
 
 func TestMatrix(t *testing.T) {
 
@@ -42,3 +47,156 @@ func TestMatrix(t *testing.T) {
 		t.Fatalf("expected same values")
 	}
 }
+
+func TestMatrixView(t *testing.T) {
+
+	na := na234.Copy()
+	mat := na.Matrix(-1, 1, -1)
+	mat.Set(1, 3, -1)
+	if na.At(1, 1, 3) != -1 {
+		t.Fatalf("expected -1, got %f", na.At(1, 1, 3))
+	}
+	vec := na.Vector(0, -1, 2)
+	(*NArray)(vec).Set(-2, 1)
+	if na.At(0, 1, 2) != -2 {
+		t.Fatalf("expected -2, got %f", na.At(0, 1, 2))
+	}
+}
+
+func TestMatrixT(t *testing.T) {
+
+	mat := na234.Copy().Matrix(1, -1, -1)
+	tr, ok := mat.T().(*Matrix)
+	if !ok {
+		t.Fatalf("expected a *Matrix, got %T", mat.T())
+	}
+	r, c := tr.Dims()
+	if r != 4 || c != 3 {
+		t.Fatalf("expected dims 4x3, got %dx%d", r, c)
+	}
+	if tr.At(3, 2) != mat.At(2, 3) {
+		t.Fatalf("expected %f, got %f", mat.At(2, 3), tr.At(3, 2))
+	}
+	// The transpose is a view.
+	tr.Set(3, 2, -7)
+	if mat.At(2, 3) != -7 {
+		t.Fatalf("expected -7, got %f", mat.At(2, 3))
+	}
+}
+
+func TestGonumMatrix(t *testing.T) {
+
+	m := na234.Matrix(1, -1, -1)
+	var d mat.Dense
+	d.Mul(m, m.T())
+	exp := MatMul(nil, na234.SubArray(1, -1, -1), na234.SubArray(1, -1, -1).Transpose())
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if e := float64(exp.At(i, j)); math.Abs(d.At(i, j)-e) > 1e-6*math.Abs(e) {
+				t.Fatalf("[%d %d]: expected %f, got %f", i, j, exp.At(i, j), d.At(i, j))
+			}
+		}
+	}
+
+	// Mutable views write to the narray.
+	na := na234.Copy()
+	var mm mat.Mutable = na.Matrix(-1, 2, -1)
+	mm.Set(1, 3, -5)
+	if na.At(1, 2, 3) != -5 {
+		t.Fatalf("expected -5, got %f", na.At(1, 2, 3))
+	}
+
+	// Raw matrices share data with float64 narrays.
+	raw := na.Matrix(0, -1, -1).RawMatrix()
+	if raw.Rows != 3 || raw.Cols != 4 || raw.Stride != 4 || raw.Data[4] != float64(na.At(0, 1, 0)) {
+		t.Fatalf("unexpected raw matrix %v", raw)
+	}
+	raw.Data[5] = 100
+	if na.At(0, 1, 1) != 100 {
+		t.Fatalf("raw matrix doesn't share data with the narray")
+	}
+	raw = na.Matrix(0, -1, -1).T().(mat.RawMatrixer).RawMatrix()
+	if raw.Rows != 4 || raw.Cols != 3 || raw.Data[1] != float64(na.At(0, 1, 0)) {
+		t.Fatalf("expected the transposed matrix, got %v", raw)
+	}
+	// Columns with a stride can't be shared.
+	col := na.Permute(0, 2, 1).Matrix(1, -1, -1)
+	raw = col.RawMatrix()
+	if raw.Stride != 3 || raw.Data[3] != col.At(1, 0) {
+		t.Fatalf("unexpected copy %v", raw)
+	}
+}
+
+func TestGonumVector(t *testing.T) {
+
+	na := na234.Copy()
+	vec := na.Vector(1, -1, 2)
+	var v mat.Vector = vec
+	if v.Len() != 3 || v.AtVec(2) != float64(na.At(1, 2, 2)) {
+		t.Fatalf("unexpected vector %v", vec)
+	}
+	if r, c := v.T().Dims(); r != 1 || c != 3 {
+		t.Fatalf("expected dims 1x3, got %dx%d", r, c)
+	}
+	dot := mat.Dot(v, v)
+	exp := Dot((*NArray)(vec), (*NArray)(vec))
+	if math.Abs(dot-float64(exp)) > 1e-6*math.Abs(float64(exp)) {
+		t.Fatalf("expected %f, got %f", exp, dot)
+	}
+	vec.SetVec(0, 7)
+	if na.At(1, 0, 2) != 7 {
+		t.Fatalf("expected 7, got %f", na.At(1, 0, 2))
+	}
+	raw := vec.RawVector()
+	if raw.N != 3 || raw.Data[0] != 7 {
+		t.Fatalf("unexpected raw vector %v", raw)
+	}
+	if !panics(func() { vec.At(0, 1) }) {
+		t.Fatalf("expected panic for column access")
+	}
+}
+
+func TestDense(t *testing.T) {
+
+	d := mat.NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	na := FromDense(d)
+	if na.Rank != 2 || na.At(1, 2) != 6 {
+		t.Fatalf("unexpected narray %v", na)
+	}
+	d.Set(0, 1, -2)
+	if na.At(0, 1) != -2 {
+		t.Fatalf("narray doesn't share data with dense")
+	}
+
+	// A view of a dense matrix.
+	sub := d.Slice(0, 2, 1, 3).(*mat.Dense)
+	if s := FromDense(sub); s.At(1, 0) != 5 || s.Shape[1] != 2 {
+		t.Fatalf("unexpected narray %v", s)
+	}
+
+	x := na234.SubArray(1, -1, -1).Copy()
+	dx := ToDense(x)
+	if r, c := dx.Dims(); r != 3 || c != 4 || dx.At(2, 1) != float64(x.At(2, 1)) {
+		t.Fatalf("unexpected dense %v", mat.Formatted(dx))
+	}
+	dx.Set(2, 1, 42)
+	if x.At(2, 1) != 42 {
+		t.Fatalf("dense doesn't share data with narray")
+	}
+	if tr := ToDense(x.Transpose()); tr.At(1, 2) != float64(x.At(2, 1)) {
+		t.Fatalf("unexpected transposed dense %v", mat.Formatted(tr))
+	}
+
+	v := mat.NewVecDense(4, []float64{1, 2, 3, 4})
+	nv := FromVecDense(v)
+	if nv.Rank != 1 || nv.At(3) != 4 {
+		t.Fatalf("unexpected narray %v", nv)
+	}
+	col := ToVecDense(x.SubArray(-1, 2))
+	if col.Len() != 3 || col.AtVec(1) != float64(x.At(1, 2)) {
+		t.Fatalf("unexpected vector %v", mat.Formatted(col))
+	}
+	if !panics(func() { ToDense(x.Reshape(12)) }) {
+		t.Fatalf("expected panic for a vector")
+	}
+}
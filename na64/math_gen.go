@@ -1,9 +1,10 @@
 // generated by narray; DO NOT EDIT
-// more info at github.com/akualab/narray
 
 package na64
 
-import "math"
+import (
+	"github.com/akualab/narray"
+)
 
 // Acosh applies math.Acosh() elementwise to a multidimensional array.
 // See math package in standard lib for details.
@@ -11,15 +12,7 @@ import "math"
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Acosh(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Acosh:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Acosh(float64(v)))
-	}
-	return out
+	return narray.Acosh[float64](out, in)
 }
 
 // Asin applies math.Asin() elementwise to a multidimensional array.
@@ -28,15 +21,7 @@ func Acosh(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Asin(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Asin:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Asin(float64(v)))
-	}
-	return out
+	return narray.Asin[float64](out, in)
 }
 
 // Acos applies math.Acos() elementwise to a multidimensional array.
@@ -45,15 +30,7 @@ func Asin(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Acos(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Acos:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Acos(float64(v)))
-	}
-	return out
+	return narray.Acos[float64](out, in)
 }
 
 // Asinh applies math.Asinh() elementwise to a multidimensional array.
@@ -62,15 +39,7 @@ func Acos(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Asinh(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Asinh:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Asinh(float64(v)))
-	}
-	return out
+	return narray.Asinh[float64](out, in)
 }
 
 // Atan applies math.Atan() elementwise to a multidimensional array.
@@ -79,15 +48,7 @@ func Asinh(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Atan(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Atan:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Atan(float64(v)))
-	}
-	return out
+	return narray.Atan[float64](out, in)
 }
 
 // Atanh applies math.Atanh() elementwise to a multidimensional array.
@@ -96,15 +57,7 @@ func Atan(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Atanh(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Atanh:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Atanh(float64(v)))
-	}
-	return out
+	return narray.Atanh[float64](out, in)
 }
 
 // Cbrt applies math.Cbrt() elementwise to a multidimensional array.
@@ -113,15 +66,7 @@ func Atanh(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Cbrt(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Cbrt:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Cbrt(float64(v)))
-	}
-	return out
+	return narray.Cbrt[float64](out, in)
 }
 
 // Erf applies math.Erf() elementwise to a multidimensional array.
@@ -130,15 +75,7 @@ func Cbrt(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Erf(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Erf:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Erf(float64(v)))
-	}
-	return out
+	return narray.Erf[float64](out, in)
 }
 
 // Erfc applies math.Erfc() elementwise to a multidimensional array.
@@ -147,15 +84,25 @@ func Erf(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Erfc(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Erfc:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Erfc(float64(v)))
-	}
-	return out
+	return narray.Erfc[float64](out, in)
+}
+
+// Erfinv applies math.Erfinv() elementwise to a multidimensional array.
+// See math package in standard lib for details.
+//
+// If 'out' is nil a new array is created.
+// Will panic if 'out' and 'in' shapes don't match.
+func Erfinv(out, in *NArray) *NArray {
+	return narray.Erfinv[float64](out, in)
+}
+
+// Erfcinv applies math.Erfcinv() elementwise to a multidimensional array.
+// See math package in standard lib for details.
+//
+// If 'out' is nil a new array is created.
+// Will panic if 'out' and 'in' shapes don't match.
+func Erfcinv(out, in *NArray) *NArray {
+	return narray.Erfcinv[float64](out, in)
 }
 
 // Exp applies math.Exp() elementwise to a multidimensional array.
@@ -164,15 +111,7 @@ func Erfc(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Exp(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Exp:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Exp(float64(v)))
-	}
-	return out
+	return narray.Exp[float64](out, in)
 }
 
 // Exp2 applies math.Exp2() elementwise to a multidimensional array.
@@ -181,15 +120,7 @@ func Exp(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Exp2(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Exp2:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Exp2(float64(v)))
-	}
-	return out
+	return narray.Exp2[float64](out, in)
 }
 
 // Expm1 applies math.Expm1() elementwise to a multidimensional array.
@@ -198,15 +129,7 @@ func Exp2(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Expm1(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Expm1:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Expm1(float64(v)))
-	}
-	return out
+	return narray.Expm1[float64](out, in)
 }
 
 // Floor applies math.Floor() elementwise to a multidimensional array.
@@ -215,15 +138,7 @@ func Expm1(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Floor(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Floor:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Floor(float64(v)))
-	}
-	return out
+	return narray.Floor[float64](out, in)
 }
 
 // Ceil applies math.Ceil() elementwise to a multidimensional array.
@@ -232,15 +147,7 @@ func Floor(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Ceil(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Ceil:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Ceil(float64(v)))
-	}
-	return out
+	return narray.Ceil[float64](out, in)
 }
 
 // Trunc applies math.Trunc() elementwise to a multidimensional array.
@@ -249,15 +156,25 @@ func Ceil(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Trunc(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Trunc:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Trunc(float64(v)))
-	}
-	return out
+	return narray.Trunc[float64](out, in)
+}
+
+// Round applies math.Round() elementwise to a multidimensional array.
+// See math package in standard lib for details.
+//
+// If 'out' is nil a new array is created.
+// Will panic if 'out' and 'in' shapes don't match.
+func Round(out, in *NArray) *NArray {
+	return narray.Round[float64](out, in)
+}
+
+// RoundToEven applies math.RoundToEven() elementwise to a multidimensional array.
+// See math package in standard lib for details.
+//
+// If 'out' is nil a new array is created.
+// Will panic if 'out' and 'in' shapes don't match.
+func RoundToEven(out, in *NArray) *NArray {
+	return narray.RoundToEven[float64](out, in)
 }
 
 // Gamma applies math.Gamma() elementwise to a multidimensional array.
@@ -266,15 +183,7 @@ func Trunc(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Gamma(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Gamma:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Gamma(float64(v)))
-	}
-	return out
+	return narray.Gamma[float64](out, in)
 }
 
 // J0 applies math.J0() elementwise to a multidimensional array.
@@ -283,15 +192,7 @@ func Gamma(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func J0(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("J0:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.J0(float64(v)))
-	}
-	return out
+	return narray.J0[float64](out, in)
 }
 
 // Y0 applies math.Y0() elementwise to a multidimensional array.
@@ -300,15 +201,7 @@ func J0(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Y0(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Y0:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Y0(float64(v)))
-	}
-	return out
+	return narray.Y0[float64](out, in)
 }
 
 // J1 applies math.J1() elementwise to a multidimensional array.
@@ -317,15 +210,7 @@ func Y0(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func J1(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("J1:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.J1(float64(v)))
-	}
-	return out
+	return narray.J1[float64](out, in)
 }
 
 // Y1 applies math.Y1() elementwise to a multidimensional array.
@@ -334,15 +219,7 @@ func J1(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Y1(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Y1:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Y1(float64(v)))
-	}
-	return out
+	return narray.Y1[float64](out, in)
 }
 
 // Log applies math.Log() elementwise to a multidimensional array.
@@ -351,15 +228,7 @@ func Y1(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Log(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Log:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Log(float64(v)))
-	}
-	return out
+	return narray.Log[float64](out, in)
 }
 
 // Log10 applies math.Log10() elementwise to a multidimensional array.
@@ -368,15 +237,7 @@ func Log(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Log10(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Log10:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Log10(float64(v)))
-	}
-	return out
+	return narray.Log10[float64](out, in)
 }
 
 // Log2 applies math.Log2() elementwise to a multidimensional array.
@@ -385,15 +246,7 @@ func Log10(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Log2(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Log2:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Log2(float64(v)))
-	}
-	return out
+	return narray.Log2[float64](out, in)
 }
 
 // Log1p applies math.Log1p() elementwise to a multidimensional array.
@@ -402,15 +255,7 @@ func Log2(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Log1p(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Log1p:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Log1p(float64(v)))
-	}
-	return out
+	return narray.Log1p[float64](out, in)
 }
 
 // Logb applies math.Logb() elementwise to a multidimensional array.
@@ -419,15 +264,7 @@ func Log1p(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Logb(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Logb:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Logb(float64(v)))
-	}
-	return out
+	return narray.Logb[float64](out, in)
 }
 
 // Cos applies math.Cos() elementwise to a multidimensional array.
@@ -436,15 +273,7 @@ func Logb(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Cos(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Cos:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Cos(float64(v)))
-	}
-	return out
+	return narray.Cos[float64](out, in)
 }
 
 // Sin applies math.Sin() elementwise to a multidimensional array.
@@ -453,15 +282,7 @@ func Cos(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Sin(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Sin:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Sin(float64(v)))
-	}
-	return out
+	return narray.Sin[float64](out, in)
 }
 
 // Sinh applies math.Sinh() elementwise to a multidimensional array.
@@ -470,15 +291,7 @@ func Sin(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Sinh(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Sinh:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Sinh(float64(v)))
-	}
-	return out
+	return narray.Sinh[float64](out, in)
 }
 
 // Cosh applies math.Cosh() elementwise to a multidimensional array.
@@ -487,15 +300,7 @@ func Sinh(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Cosh(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Cosh:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Cosh(float64(v)))
-	}
-	return out
+	return narray.Cosh[float64](out, in)
 }
 
 // Tan applies math.Tan() elementwise to a multidimensional array.
@@ -504,15 +309,7 @@ func Cosh(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Tan(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Tan:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Tan(float64(v)))
-	}
-	return out
+	return narray.Tan[float64](out, in)
 }
 
 // Tanh applies math.Tanh() elementwise to a multidimensional array.
@@ -521,121 +318,65 @@ func Tan(out, in *NArray) *NArray {
 // If 'out' is nil a new array is created.
 // Will panic if 'out' and 'in' shapes don't match.
 func Tanh(out, in *NArray) *NArray {
-	if out == nil {
-		out = New(in.Shape...)
-	} else if !EqualShape(out, in) {
-		panic("Tanh:narrays must have equal shape.")
-	}
-	for k, v := range in.Data {
-		out.Data[k] = float64(math.Tanh(float64(v)))
-	}
-	return out
+	return narray.Tanh[float64](out, in)
 }
 
 // Atan2 applies math.Atan2() elementwise to two multidimensional arrays.
 // See math package in standard lib for details.
 //
 // If out is nil a new array is created.
-// Will panic if 'out', 'a' and 'b' shapes don't match.
+// Will panic if 'a' and 'b' shapes can't be broadcast or if
+// the shape of 'out' doesn't match. See BroadcastShapes.
 func Atan2(out, a, b *NArray) *NArray {
-	if out == nil {
-		out = New(a.Shape...)
-	}
-	if !EqualShape(out, a, b) {
-		panic("Atan2:narrays must have equal shape.")
-	}
-	for k, v := range a.Data {
-		out.Data[k] = float64(math.Atan2(float64(v), float64(b.Data[k])))
-	}
-	return out
+	return narray.Atan2[float64](out, a, b)
 }
 
 // Dim applies math.Dim() elementwise to two multidimensional arrays.
 // See math package in standard lib for details.
 //
 // If out is nil a new array is created.
-// Will panic if 'out', 'a' and 'b' shapes don't match.
+// Will panic if 'a' and 'b' shapes can't be broadcast or if
+// the shape of 'out' doesn't match. See BroadcastShapes.
 func Dim(out, a, b *NArray) *NArray {
-	if out == nil {
-		out = New(a.Shape...)
-	}
-	if !EqualShape(out, a, b) {
-		panic("Dim:narrays must have equal shape.")
-	}
-	for k, v := range a.Data {
-		out.Data[k] = float64(math.Dim(float64(v), float64(b.Data[k])))
-	}
-	return out
+	return narray.Dim[float64](out, a, b)
 }
 
 // Hypot applies math.Hypot() elementwise to two multidimensional arrays.
 // See math package in standard lib for details.
 //
 // If out is nil a new array is created.
-// Will panic if 'out', 'a' and 'b' shapes don't match.
+// Will panic if 'a' and 'b' shapes can't be broadcast or if
+// the shape of 'out' doesn't match. See BroadcastShapes.
 func Hypot(out, a, b *NArray) *NArray {
-	if out == nil {
-		out = New(a.Shape...)
-	}
-	if !EqualShape(out, a, b) {
-		panic("Hypot:narrays must have equal shape.")
-	}
-	for k, v := range a.Data {
-		out.Data[k] = float64(math.Hypot(float64(v), float64(b.Data[k])))
-	}
-	return out
+	return narray.Hypot[float64](out, a, b)
 }
 
 // Mod applies math.Mod() elementwise to two multidimensional arrays.
 // See math package in standard lib for details.
 //
 // If out is nil a new array is created.
-// Will panic if 'out', 'a' and 'b' shapes don't match.
+// Will panic if 'a' and 'b' shapes can't be broadcast or if
+// the shape of 'out' doesn't match. See BroadcastShapes.
 func Mod(out, a, b *NArray) *NArray {
-	if out == nil {
-		out = New(a.Shape...)
-	}
-	if !EqualShape(out, a, b) {
-		panic("Mod:narrays must have equal shape.")
-	}
-	for k, v := range a.Data {
-		out.Data[k] = float64(math.Mod(float64(v), float64(b.Data[k])))
-	}
-	return out
+	return narray.Mod[float64](out, a, b)
 }
 
 // Pow applies math.Pow() elementwise to two multidimensional arrays.
 // See math package in standard lib for details.
 //
 // If out is nil a new array is created.
-// Will panic if 'out', 'a' and 'b' shapes don't match.
+// Will panic if 'a' and 'b' shapes can't be broadcast or if
+// the shape of 'out' doesn't match. See BroadcastShapes.
 func Pow(out, a, b *NArray) *NArray {
-	if out == nil {
-		out = New(a.Shape...)
-	}
-	if !EqualShape(out, a, b) {
-		panic("Pow:narrays must have equal shape.")
-	}
-	for k, v := range a.Data {
-		out.Data[k] = float64(math.Pow(float64(v), float64(b.Data[k])))
-	}
-	return out
+	return narray.Pow[float64](out, a, b)
 }
 
 // Remainder applies math.Remainder() elementwise to two multidimensional arrays.
 // See math package in standard lib for details.
 //
 // If out is nil a new array is created.
-// Will panic if 'out', 'a' and 'b' shapes don't match.
+// Will panic if 'a' and 'b' shapes can't be broadcast or if
+// the shape of 'out' doesn't match. See BroadcastShapes.
 func Remainder(out, a, b *NArray) *NArray {
-	if out == nil {
-		out = New(a.Shape...)
-	}
-	if !EqualShape(out, a, b) {
-		panic("Remainder:narrays must have equal shape.")
-	}
-	for k, v := range a.Data {
-		out.Data[k] = float64(math.Remainder(float64(v), float64(b.Data[k])))
-	}
-	return out
+	return narray.Remainder[float64](out, a, b)
 }
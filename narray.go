@@ -0,0 +1,1240 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:generate go run genarray.go
+
+/*
+Package narray provides functions to opearate with multidimensional arrays of numbers. The NArray object is a dense, fixed-size, array of rank n. The element type T is a float, complex or integer type, see Elem.
+
+The shape is a vector with the size of each dimension. Typical cases:
+
+	type      rank   example
+	--------------------------------
+	scalar    0      na := New[float64]()
+	vector    1      na := New[float64](12)
+	matrix    2      na := New[float64](5,17)
+	cube      3      na := New[float64](2,3,5)
+
+Packages na32, na64, nc64, nc128, ni32, ni64 and nu8 alias the NArray type
+for each element type and wrap the functions of this package, so that the
+type parameter doesn't need to be spelled out:
+
+	import "github.com/akualab/narray/na64"
+
+	na := na64.New(5, 17)
+*/
+package narray
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// The NArray object.
+type NArray[T Elem] struct {
+	// The rank or order or degree of the narray is the dimensionality required to represent it. (eg. The rank of a vector is 1)
+	Rank int `json:"rank"`
+	// The shape is an int slice that contains the size of each dimension. Subscripts range from zero to s-1. Where s is the size of a dimension.
+	Shape []int `json:"shape"`
+	// The data is stored as a slice of T numbers.
+	Data []T `json:"data"`
+	// Strides for each dimension.
+	Strides []int `json:"strides"`
+	// Offset is the position of the first element in Data. It is non-zero for views created by SubArray and friends.
+	Offset int `json:"offset,omitempty"`
+}
+
+// New creates a new n-dimensional array.
+func New[T Elem](shape ...int) *NArray[T] {
+
+	strides, size := rowMajor(shape)
+	return &NArray[T]{
+		Rank:    len(shape),
+		Shape:   shape,
+		Data:    make([]T, size, size),
+		Strides: strides,
+	}
+}
+
+// rowMajor returns the strides and the size of a compact
+// row-major array with the given shape.
+func rowMajor(shape []int) (strides []int, size int) {
+
+	size = 1
+	for _, v := range shape {
+		size *= v
+	}
+	rank := len(shape)
+	strides = make([]int, rank, rank)
+	s := 1
+	for i := (rank - 1); i >= 0; i-- {
+		strides[i] = s
+		s *= shape[i]
+	}
+	return
+}
+
+// NewArray creates a new n-dimensional array with content of a slice
+// The size of the slice must match the product of the slice,
+// otherwise a panic will occur
+func NewArray[T Elem](a []T, shape ...int) *NArray[T] {
+
+	strides, size := rowMajor(shape)
+	if len(a) != size {
+		panic(&ShapeError{Op: "NewArray", Msg: fmt.Sprintf("slice of length [%d] doesn't match size of shape", len(a)), Shapes: [][]int{shape}})
+	}
+	return &NArray[T]{
+		Rank:    len(shape),
+		Shape:   shape,
+		Data:    a,
+		Strides: strides,
+	}
+}
+
+// Norm creates a new n-dimensional array whose
+// elements are drawn from a Normal probability density function.
+func Norm[T Float](r *rand.Rand, mean, sd T, shape ...int) *NArray[T] {
+
+	na := New[T](shape...)
+	for i := range na.Data {
+		na.Data[i] = T(r.NormFloat64())*sd + mean
+	}
+	return na
+}
+
+// Rand creates a new n-dimensional array whose
+// elements are set using the rand.Float64 function.
+// Values are pseudo-random numbers in [0.0,1.0).
+func Rand[T Float](r *rand.Rand, shape ...int) *NArray[T] {
+
+	na := New[T](shape...)
+	for i := range na.Data {
+		if kindOf[T]().is64() {
+			na.Data[i] = T(r.Float64())
+		} else {
+			na.Data[i] = T(r.Float32())
+		}
+	}
+	return na
+}
+
+// Size returns the number of elements in the narray.
+func (na *NArray[T]) Size() int {
+
+	size := 1
+	for _, v := range na.Shape {
+		size *= v
+	}
+	return size
+}
+
+// IsContiguous returns true if the elements of the narray are
+// stored in row-major order in a contiguous segment of Data.
+// Views created by SubArray may not be contiguous.
+func (na *NArray[T]) IsContiguous() bool {
+
+	s := 1
+	for i := na.Rank - 1; i >= 0; i-- {
+		if na.Shape[i] != 1 && na.Strides[i] != s {
+			return false
+		}
+		s *= na.Shape[i]
+	}
+	return true
+}
+
+// Contiguous returns the narray if it is contiguous, otherwise returns a
+// contiguous copy. Use it to materialize a view before operating on Data.
+func (na *NArray[T]) Contiguous() *NArray[T] {
+
+	if na.IsContiguous() {
+		return na
+	}
+	return na.Copy()
+}
+
+// At returns the value for indices.
+// Will panic with an *IndexError if the indices are out of range.
+func (na *NArray[T]) At(indices ...int) T {
+
+	return na.Data[na.Index(indices...)]
+}
+
+// Set value for indices.
+func (na *NArray[T]) Set(v T, indices ...int) {
+
+	na.Data[na.Index(indices...)] = v
+}
+
+// Index transforms a set of subscripts to a an index in the underlying one-dimensional slice.
+// Will panic with an *IndexError if the number of indices doesn't match the rank
+// or if an index is out of range.
+func (na *NArray[T]) Index(indices ...int) int {
+	return index(na.Shape, na.Strides, na.Offset, indices)
+}
+
+// index returns the position of the element at indices for
+// the given shape, strides and offset.
+func index(shape, strides []int, offset int, indices []int) int {
+
+	if len(indices) != len(shape) {
+		panic(indexError(indices, shape))
+	}
+	idx := offset
+	for k, v := range indices {
+		if v < 0 || v >= shape[k] {
+			panic(indexError(indices, shape))
+		}
+		idx += v * strides[k]
+	}
+	return idx
+}
+
+// ReverseIndex converts a linear index to narray indices.
+func (na *NArray[T]) ReverseIndex(idx int) []int {
+
+	res := make([]int, na.Rank, na.Rank)
+	temp := idx
+	p := 1
+	for k := 1; k < na.Rank; k++ {
+		p *= na.Shape[k]
+	}
+	for i := 0; i < na.Rank; i++ {
+		res[i] = temp / p
+		temp = temp % p
+		if (i + 1) < na.Rank {
+			p /= na.Shape[i+1]
+		}
+	}
+	return res
+}
+
+// Copy returns a copy on the narray.
+// The copy is always contiguous, even when the narray is a view.
+func (na *NArray[T]) Copy() *NArray[T] {
+
+	newna := New[T](na.Shape...)
+	na.gather(newna.Data)
+	return newna
+}
+
+// ApplyFunc is a type for creating custom functions.
+type ApplyFunc[T Elem] func(x T) T
+
+// Apply function of type ApplyFunc to a multidimensional array.
+// If out is nil, a new object is allocated.
+func Apply[T Elem](out, in *NArray[T], fn ApplyFunc[T]) *NArray[T] {
+
+	if out == nil {
+		out = New[T](in.Shape...)
+	}
+	unaryOp(func(o, x []T) {
+		for i, v := range x {
+			o[i] = fn(v)
+		}
+	}, out, in)
+	return out
+}
+
+// EqualShape returns true if all the arrays have equal length,
+// and false otherwise. Returns true if there is only one input array.
+func EqualShape[T Elem](x *NArray[T], ys ...*NArray[T]) bool {
+	for _, y := range ys {
+		if !equalShapes(x.Shape, y.Shape) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalShapes returns true if the shapes are equal.
+func equalShapes(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// BroadcastShapes returns the shape that results from broadcasting shapes.
+//
+// Shapes are aligned on their trailing dimensions and missing leading
+// dimensions are treated as having size 1. Two dimensions are compatible
+// when they are equal or one of them is 1, in which case the array is
+// repeated along that dimension. For example:
+//
+//	a      (3d array): 15 x 3 x 5
+//	b      (2d array):      3 x 1
+//	result (3d array): 15 x 3 x 5
+//
+// Returns a *ShapeError if the shapes are not compatible.
+func BroadcastShapes(shapes ...[]int) ([]int, error) {
+
+	rank := 0
+	for _, shape := range shapes {
+		if len(shape) > rank {
+			rank = len(shape)
+		}
+	}
+	res := make([]int, rank, rank)
+	for i := range res {
+		res[i] = 1
+	}
+	for _, shape := range shapes {
+		for k, d := range shape {
+			i := rank - len(shape) + k
+			switch {
+			case d == res[i] || d == 1:
+			case res[i] == 1:
+				res[i] = d
+			default:
+				return nil, &ShapeError{Op: "BroadcastShapes", Msg: "shapes can't be broadcast together", Shapes: shapes}
+			}
+		}
+	}
+	return res, nil
+}
+
+// Broadcast returns a view of the narray expanded to shape.
+// Dimensions of size 1 are repeated using a zero stride, no values
+// are copied. See BroadcastShapes for the rules.
+// Will panic if the narray can't be broadcast to shape.
+func (na *NArray[T]) Broadcast(shape ...int) *NArray[T] {
+
+	rank := len(shape)
+	if na.Rank > rank {
+		panic(&ShapeError{Op: "Broadcast", Msg: "can't broadcast shape", Shapes: [][]int{na.Shape, shape}})
+	}
+	ns := make([]int, rank, rank)
+	strides := make([]int, rank, rank)
+	copy(ns, shape)
+	for k := 0; k < na.Rank; k++ {
+		i := rank - na.Rank + k
+		switch {
+		case na.Shape[k] == shape[i]:
+			strides[i] = na.Strides[k]
+		case na.Shape[k] == 1:
+			strides[i] = 0
+		default:
+			panic(&ShapeError{Op: "Broadcast", Msg: "can't broadcast shape", Shapes: [][]int{na.Shape, shape}})
+		}
+	}
+	return &NArray[T]{
+		Rank:    rank,
+		Shape:   ns,
+		Data:    na.Data,
+		Strides: strides,
+		Offset:  na.Offset,
+	}
+}
+
+// Inc increments the value of an narray element.
+func (na *NArray[T]) Inc(v T, indices ...int) {
+
+	na.Data[na.Index(indices...)] += v
+}
+
+// MaxElem compares value to element and replaces element if
+// value is greater than element.
+// Complex values are ordered by real part first and then by imaginary part.
+func (na *NArray[T]) MaxElem(v T, indices ...int) {
+
+	idx := na.Index(indices...)
+	if less(na.Data[idx], v) {
+		na.Data[idx] = v
+	}
+}
+
+// MinElem compares value to element and replaces element if
+// value is less than element.
+// Complex values are ordered by real part first and then by imaginary part.
+func (na *NArray[T]) MinElem(v T, indices ...int) {
+
+	idx := na.Index(indices...)
+	if less(v, na.Data[idx]) {
+		na.Data[idx] = v
+	}
+}
+
+// Add adds narrays elementwise.
+//
+//	out = sum_i(in[i])
+//
+// Will panic if there are not at least two input narrays
+// or if narray shapes can't be broadcast. See BroadcastShapes.
+// If out is nil a new array is created.
+func Add[T Elem](out *NArray[T], in ...*NArray[T]) *NArray[T] {
+
+	if len(in) < 2 {
+		return nil
+	}
+	out = broadcastOut("Add", out, in...)
+
+	binaryOp(addSlice, out, in[0], in[1])
+
+	// Multiply each following, if more than two arguments.
+	for k := 2; k < len(in); k++ {
+		binaryOp(addSlice, out, out, in[k])
+	}
+
+	return out
+}
+
+// Mul multiplies narrays elementwise.
+//
+//	out = prod_i(in[i])
+//
+// Will panic if there are not at least two input narrays
+// or if narray shapes can't be broadcast. See BroadcastShapes.
+// If out is nil a new array is created.
+func Mul[T Elem](out *NArray[T], in ...*NArray[T]) *NArray[T] {
+
+	if len(in) < 2 {
+		panic("not in enough arguments")
+	}
+	out = broadcastOut("Mul", out, in...)
+
+	binaryOp(mulSlice, out, in[0], in[1])
+
+	// Multiply each following, if more than two arguments.
+	for k := 2; k < len(in); k++ {
+		binaryOp(mulSlice, out, out, in[k])
+	}
+	return out
+}
+
+// Dot computes the sum of the elementwise products of
+// the input arrays.
+//
+//	y = sum_{i = 0}^(N-1) x0[i]*x1[i]*...x_n-1[i]
+//
+// Complex values are not conjugated. See MatMul for matrix products.
+// Will panic if there are not at least two input narrays
+// or if narray shapes don't match.
+func Dot[T Elem](in ...*NArray[T]) T {
+
+	if len(in) < 2 {
+		panic("not in enough arguments")
+	}
+	if !EqualShape(in[0], in...) {
+		panic(shapeError("Dot", "narrays must have equal shape", in...))
+	}
+	out := New[T](in[0].Shape...)
+	binaryOp(mulSlice, out, in[0], in[1])
+
+	// Multiply each following, if more than two arguments.
+	for k := 2; k < len(in); k++ {
+		binaryOp(mulSlice, out, out, in[k])
+	}
+	return sliceSum(out.Data)
+}
+
+// Div divides narrays elementwise.
+//
+//	out = in[0] / in[1] / in[2] ....
+//
+// Will panic if there are not at least two input narrays
+// or if narray shapes can't be broadcast. See BroadcastShapes.
+// If out is nil a new array is created.
+func Div[T Elem](out *NArray[T], in ...*NArray[T]) *NArray[T] {
+
+	if len(in) < 2 {
+		panic("not in enough arguments")
+	}
+	out = broadcastOut("Div", out, in...)
+
+	binaryOp(divSlice, out, in[0], in[1])
+
+	// Multiply each following, if more than two arguments.
+	for k := 2; k < len(in); k++ {
+		binaryOp(divSlice, out, out, in[k])
+	}
+	return out
+}
+
+// Sub subtracts narrays elementwise.
+//
+//	out = in[0] - in[1] - in[2] ....
+//
+// Will panic if there are not at least two input narrays
+// or if narray shapes can't be broadcast. See BroadcastShapes.
+// If out is nil a new array is created.
+func Sub[T Elem](out *NArray[T], in ...*NArray[T]) *NArray[T] {
+
+	if len(in) < 2 {
+		panic("not in enough arguments")
+	}
+	out = broadcastOut("Sub", out, in...)
+
+	binaryOp(subSlice, out, in[0], in[1])
+
+	// Multiply each following, if more than two arguments.
+	for k := 2; k < len(in); k++ {
+		binaryOp(subSlice, out, out, in[k])
+	}
+	return out
+}
+
+// AddConst adds const to an narray elementwise.
+// out = in + c
+// If out is nil a new array is created.
+func AddConst[T Elem](out *NArray[T], in *NArray[T], c T) *NArray[T] {
+
+	if out == nil {
+		out = New[T](in.Shape...)
+	} else {
+		if !EqualShape(out, in) {
+			panic(shapeError("AddConst", "narrays must have equal shape", out, in))
+		}
+	}
+	unaryOp(func(o, x []T) { caddSlice(o, x, c) }, out, in)
+	return out
+}
+
+// AddScaled adds a scaled narray elementwise.
+// y = y + a * x
+// If y is nil a new array is created.
+func AddScaled[T Elem](y *NArray[T], x *NArray[T], a T) *NArray[T] {
+
+	if y == nil {
+		y = New[T](x.Shape...)
+	} else {
+		if !EqualShape(y, x) {
+			panic(shapeError("AddScaled", "narrays must have equal shape", y, x))
+		}
+	}
+	unaryOp(func(o, x []T) { addScaledSlice(o, x, a) }, y, x)
+	return y
+}
+
+// Scale multiplies an narray by a factor elementwise.
+// out = c * in
+// If out is nil a new array is created.
+func Scale[T Elem](out *NArray[T], in *NArray[T], c T) *NArray[T] {
+
+	if out == nil {
+		out = New[T](in.Shape...)
+	} else {
+		if !EqualShape(out, in) {
+			panic(shapeError("Scale", "narrays must have equal shape", out, in))
+		}
+	}
+	unaryOp(func(o, x []T) { cmulSlice(o, x, c) }, out, in)
+	return out
+}
+
+// Rcp returns reciprocal values of narrays elementwise.
+// out = 1.0 / in
+// If out is nil a new array is created.
+func Rcp[T Elem](out, in *NArray[T]) *NArray[T] {
+	if out == nil {
+		out = New[T](in.Shape...)
+	} else {
+		if !EqualShape(out, in) {
+			panic(shapeError("Rcp", "narrays must have equal shape", out, in))
+		}
+	}
+	unaryOp(func(o, x []T) { cdivSlice(o, x, 1.0) }, out, in)
+	return out
+}
+
+// Sqrt returns square root values of narrays elementwise.
+// out = math.Sqrt(in)
+// If out is nil a new array is created.
+func Sqrt[T Float](out, in *NArray[T]) *NArray[T] {
+	if out == nil {
+		out = New[T](in.Shape...)
+	} else {
+		if !EqualShape(out, in) {
+			panic(shapeError("Sqrt", "narrays must have equal shape", out, in))
+		}
+	}
+	unaryOp(sqrtSlice, out, in)
+	return out
+}
+
+// Abs returns square root values of narrays elementwise.
+// out = math.Abs(in)
+// If out is nil a new array is created.
+func Abs[T Float](out, in *NArray[T]) *NArray[T] {
+	if out == nil {
+		out = New[T](in.Shape...)
+	} else {
+		if !EqualShape(out, in) {
+			panic(shapeError("Abs", "narrays must have equal shape", out, in))
+		}
+	}
+	unaryOp(absSlice, out, in)
+	return out
+}
+
+// Max returns the max value in the narray.
+// Complex values are ordered by real part first and then by imaginary part.
+func (na *NArray[T]) Max() T {
+	if na == nil || na.Size() == 0 {
+		panic("unable to take max of nil or zero-sizes array")
+	}
+	data := na.Contiguous().data()
+	switch kindOf[T]() {
+	case float64Kind:
+		return fromFloat[T](maxSliceElement(f64(data)))
+	case float32Kind:
+		return fromFloat[T](maxSliceElement(f32(data)))
+	}
+	return data[argMaxSlice(data)]
+}
+
+// MaxIdx returns the max value and corresponding indices.
+// Complex values are ordered by real part first and then by imaginary part.
+func (na *NArray[T]) MaxIdx() (T, []int) {
+	if na == nil || na.Size() == 0 {
+		panic("unable to take max of nil or zero-sizes array")
+	}
+	data := na.Contiguous().data()
+	offset := argMaxSlice(data)
+	return data[offset], na.ReverseIndex(offset)
+}
+
+// MaxArray compare input narrays and returns an narray containing
+// the element-wise maxima.
+//
+//	out[i,j,k,...] = max(in0[i,j,k,...], in1[i,j,k,...], ...)
+//
+// Will panic if there are not at least two input narray
+// or if narray shapes can't be broadcast. See BroadcastShapes.
+// If out is nil a new array is created.
+func MaxArray[T Real](out *NArray[T], in ...*NArray[T]) *NArray[T] {
+
+	if len(in) < 2 {
+		panic("not in enough input narrays")
+	}
+	out = broadcastOut("MaxArray", out, in...)
+
+	binaryOp(maxSlice, out, in[0], in[1])
+
+	// Also add each following, if more than two arguments.
+	for k := 2; k < len(in); k++ {
+		binaryOp(maxSlice, out, out, in[k])
+	}
+	return out
+}
+
+// Copysign returns values with the magnitude of a and the sign of b
+// for each element of the arrays.
+// Will panic if narray shapes can't be broadcast. See BroadcastShapes.
+// If out is nil a new array is created.
+func Copysign[T Float](out, a, b *NArray[T]) *NArray[T] {
+
+	out = broadcastOut("Copysign", out, a, b)
+
+	binaryOp(csignSlice, out, a, b)
+
+	return out
+}
+
+// Min returns the min value in the narray.
+// Complex values are ordered by real part first and then by imaginary part.
+func (na *NArray[T]) Min() T {
+	if na == nil || na.Size() == 0 {
+		panic("unable to take min of nil or zero-sizes array")
+	}
+	data := na.Contiguous().data()
+	switch kindOf[T]() {
+	case float64Kind:
+		return fromFloat[T](minSliceElement(f64(data)))
+	case float32Kind:
+		return fromFloat[T](minSliceElement(f32(data)))
+	}
+	return data[argMinSlice(data)]
+}
+
+// MinIdx returns the min value and corresponding indices.
+// Complex values are ordered by real part first and then by imaginary part.
+func (na *NArray[T]) MinIdx() (T, []int) {
+	if na == nil || na.Size() == 0 {
+		panic("unable to take min of nil or zero-sizes array")
+	}
+	data := na.Contiguous().data()
+	offset := argMinSlice(data)
+	return data[offset], na.ReverseIndex(offset)
+}
+
+// MinArray compare input narrays and returns an narray containing
+// the element-wise minima.
+//
+//	out[i,j,k,...] = min(in0[i,j,k,...], in1[i,j,k,...], ...)
+//
+// Will panic if there are not at least two input narray
+// or if narray shapes can't be broadcast. See BroadcastShapes.
+// If out is nil a new array is created.
+func MinArray[T Real](out *NArray[T], in ...*NArray[T]) *NArray[T] {
+
+	if len(in) < 2 {
+		panic("not in enough input narrays")
+	}
+	out = broadcastOut("MinArray", out, in...)
+
+	binaryOp(minSlice, out, in[0], in[1])
+
+	// Also add each following, if more than two arguments.
+	for k := 2; k < len(in); k++ {
+		binaryOp(minSlice, out, out, in[k])
+	}
+
+	return out
+}
+
+// Prod returns the products of all the elements in the narray.
+func (na *NArray[T]) Prod() T {
+
+	p := T(1.0)
+	for _, v := range na.Contiguous().data() {
+		p *= v
+	}
+	return p
+}
+
+// Sum returns the sum of all the elements in the narray.
+func (na *NArray[T]) Sum() T {
+	return sliceSum(na.Contiguous().data())
+}
+
+// SetValue sets all elements to value.
+func (na *NArray[T]) SetValue(v T) *NArray[T] {
+
+	na.walk(func(k, idx int) {
+		na.Data[idx] = v
+	})
+	return na
+}
+
+// Encode converts values in-place as follows:
+//
+//	Inf to math.MaxFloat64
+//	-Inf to -math.MaxFloat64
+//	NaN ro 0
+//
+// Returns the indices of the modified values as follows:
+//
+//	Values in inf => na.Data[abs(v)] = sign(v) * Inf
+//	Values in nan => na.Data[v] = NaN
+//
+// For complex narrays, the indices refer to the real and imaginary
+// parts, where Data[k] has parts 2k and 2k+1.
+// Integer narrays are not modified.
+func (na *NArray[T]) Encode() (inf, nan []int) {
+	k := kindOf[T]()
+	switch {
+	case k.isInteger():
+		return []int{}, []int{}
+	case k.is64():
+		return encode(f64(na.Data))
+	}
+	return encode(f32(na.Data))
+}
+
+func encode[F Float](data []F) (inf, nan []int) {
+
+	inf = []int{}
+	nan = []int{}
+	for k, v := range data {
+		switch {
+		case math.IsInf(float64(v), 1):
+			data[k] = maxValue[F]()
+			inf = append(inf, k)
+		case math.IsInf(float64(v), -1):
+			data[k] = -maxValue[F]()
+			inf = append(inf, -k)
+		case math.IsNaN(float64(v)):
+			data[k] = 0
+			nan = append(nan, k)
+		}
+	}
+	return
+}
+
+// Decode converts values in-place.
+// See Encode() for details.
+func (na *NArray[T]) Decode(inf, nan []int) {
+	k := kindOf[T]()
+	switch {
+	case k.isInteger():
+	case k.is64():
+		decode(f64(na.Data), inf, nan)
+	default:
+		decode(f32(na.Data), inf, nan)
+	}
+}
+
+func decode[F Float](data []F, inf, nan []int) {
+	pInf := F(math.Inf(1))
+	nInf := F(math.Inf(-1))
+	fNan := F(math.NaN())
+
+	for _, v := range inf {
+		if v >= 0 {
+			data[v] = pInf
+		} else {
+			data[-v] = nInf
+		}
+	}
+	for _, v := range nan {
+		data[v] = fNan
+	}
+}
+
+// SubArray returns a view of lower rank as follows:
+//
+// Example, given an narray with shape 2x3x4 (rank=3), return the subarray
+// of rank=2 corresponding to dim[2]=1
+//
+//	x := New(2,3,4)
+//	y := x.SubArray(-1,-1,1) // use -1 to select a dimension. Put a 1 in dim=2 (third argument).
+//	// y = {x(0,0,1), x(0,1,1), x(0,2,1), x(1,0,1), ...}
+//
+// The subarray shares Data with the original narray, setting values in the
+// subarray modifies the original narray. Use Copy to get an independent narray.
+// Will panic with an *IndexError if the number of indices doesn't match the
+// rank or an index is out of range.
+func (na *NArray[T]) SubArray(query ...int) *NArray[T] {
+
+	if len(na.Shape) == 0 {
+		panic(shapeError("SubArray", "cannot get subarray from narray with rank=0", na))
+	}
+	if len(query) != na.Rank {
+		panic(indexError(query, na.Shape))
+	}
+
+	var ns []int  // new shape
+	var nst []int // new strides
+	offset := na.Offset
+	for k, v := range query {
+		if v >= na.Shape[k] {
+			panic(indexError(query, na.Shape))
+		}
+		if v < 0 {
+			ns = append(ns, na.Shape[k])
+			nst = append(nst, na.Strides[k])
+		} else {
+			offset += v * na.Strides[k]
+		}
+	}
+	return &NArray[T]{
+		Rank:    len(ns),
+		Shape:   ns,
+		Data:    na.Data,
+		Strides: nst,
+		Offset:  offset,
+	}
+}
+
+// Reshape returns an narray with a new shape. The returned narray
+// shares the underlying data with the original narray, no values are copied.
+// If the narray is a non-contiguous view, a contiguous copy is reshaped.
+//
+// One dimension may be set to -1, its size is inferred from the
+// total number of elements and the remaining dimensions.
+//
+//	x := New(10, 13)
+//	y := x.Reshape(-1)     // vector of size 130
+//	z := y.Reshape(5, -1)  // matrix of size 5x26
+//
+// Will panic with a *ShapeError if the total size of the new shape doesn't match
+// the size of the narray.
+func (na *NArray[T]) Reshape(dim ...int) *NArray[T] {
+
+	size := na.Size()
+	shape := make([]int, len(dim), len(dim))
+	copy(shape, dim)
+	infer := -1
+	n := 1
+	for k, v := range shape {
+		switch {
+		case v == -1 && infer >= 0:
+			panic(&ShapeError{Op: "Reshape", Msg: "only one dimension can be -1", Shapes: [][]int{dim}})
+		case v == -1:
+			infer = k
+		case v < 0:
+			panic(&ShapeError{Op: "Reshape", Msg: fmt.Sprintf("invalid dimension [%d]", v), Shapes: [][]int{dim}})
+		default:
+			n *= v
+		}
+	}
+	if infer >= 0 {
+		if n == 0 || size%n != 0 {
+			panic(&ShapeError{Op: "Reshape", Msg: fmt.Sprintf("can't infer dimension for narray of size [%d]", size), Shapes: [][]int{dim}})
+		}
+		shape[infer] = size / n
+		n *= shape[infer]
+	}
+	if n != size {
+		panic(&ShapeError{Op: "Reshape", Msg: "can't reshape narray", Shapes: [][]int{na.Shape, dim}})
+	}
+	return NewArray(na.Contiguous().data(), shape...)
+}
+
+// Permute returns a view of the narray with the axes reordered. Axis k of
+// the result is axis axes[k] of the narray. No values are copied, use Copy
+// to get a contiguous narray with the new layout.
+//
+//	x := New(2, 3, 4)
+//	y := x.Permute(2, 0, 1) // y has shape 4x2x3 and y.At(k, i, j) == x.At(i, j, k)
+//
+// Will panic with a *ShapeError if axes is not a permutation of the axes of the narray.
+func (na *NArray[T]) Permute(axes ...int) *NArray[T] {
+
+	if len(axes) != na.Rank {
+		panic(shapeError("Permute", fmt.Sprintf("expected [%d] axes, got [%d]", na.Rank, len(axes)), na))
+	}
+	seen := make([]bool, na.Rank, na.Rank)
+	shape := make([]int, na.Rank, na.Rank)
+	strides := make([]int, na.Rank, na.Rank)
+	for k, a := range axes {
+		if a < 0 || a >= na.Rank || seen[a] {
+			panic(shapeError("Permute", fmt.Sprintf("axes %v are not a permutation of the narray axes", axes), na))
+		}
+		seen[a] = true
+		shape[k] = na.Shape[a]
+		strides[k] = na.Strides[a]
+	}
+	return &NArray[T]{
+		Rank:    na.Rank,
+		Shape:   shape,
+		Data:    na.Data,
+		Strides: strides,
+		Offset:  na.Offset,
+	}
+}
+
+// Transpose returns a view of a matrix with rows and columns swapped.
+// Use Copy to get a contiguous transposed matrix.
+// Will panic if the rank of the narray is not two.
+func (na *NArray[T]) Transpose() *NArray[T] {
+
+	if na.Rank != 2 {
+		panic(shapeError("Transpose", "narray must have rank equal two", na))
+	}
+	return na.Permute(1, 0)
+}
+
+// Sprint prints narray elements when f returns true.
+// index is the linear index of an narray.
+func (na *NArray[T]) Sprint(f func(na *NArray[T], index int) bool) string {
+
+	format := "] => %f\n"
+	if kindOf[T]().isInteger() {
+		format = "] => %d\n"
+	}
+	b := bytes.NewBufferString(fmt.Sprintln("narray rank:  ", na.Rank))
+	_, _ = b.WriteString(fmt.Sprintln("narray shape: ", na.Shape))
+	it := na.Iter()
+	for k := 0; it.Next(); k++ {
+		if f(na, k) {
+			_, _ = b.WriteString("[")
+			for axis, av := range it.Index() {
+				_, _ = b.WriteString(formatted(av, na.Shape[axis]-1))
+			}
+			_, _ = b.WriteString(fmt.Sprintf(format, it.Value()))
+		}
+	}
+	return b.String()
+}
+
+// Read unmarshals json data from an io.Reader into an narray struct.
+func Read[T Elem](r io.Reader) (*NArray[T], error) {
+	dec := json.NewDecoder(r)
+	var na NArray[T]
+	err := dec.Decode(&na)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return &na, nil
+}
+
+// ReadFile unmarshals json data from a file into an narray struct.
+func ReadFile[T Elem](fn string) (*NArray[T], error) {
+
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read[T](f)
+}
+
+// Write writes narray to an io.Writer.
+func (na *NArray[T]) Write(w io.Writer) error {
+
+	enc := json.NewEncoder(w)
+	err := enc.Encode(na)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// WriteFile writes an narray to a file.
+func (na *NArray[T]) WriteFile(fn string) error {
+
+	e := os.MkdirAll(filepath.Dir(fn), 0755)
+	if e != nil {
+		return e
+	}
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	ee := na.Write(f)
+	if ee != nil {
+		return ee
+	}
+	return nil
+}
+
+// ToJSON returns a json string.
+func (na *NArray[T]) ToJSON() (string, error) {
+	var b bytes.Buffer
+	err := na.Write(&b)
+	return b.String(), err
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+// The custom marshaller is needed to encode Inf/NaN values.
+// Complex values are encoded as [real, imag] pairs.
+func (na *NArray[T]) MarshalJSON() ([]byte, error) {
+
+	ena := na.Copy()
+	inf, nan := ena.Encode()
+	return json.Marshal(struct {
+		Rank    int         `json:"rank"`
+		Shape   []int       `json:"shape"`
+		Data    interface{} `json:"data"`
+		Strides []int       `json:"strides"`
+		Inf     []int       `json:"inf,omitempty"`
+		NaN     []int       `json:"nan,omitempty"`
+	}{
+		Rank:    ena.Rank,
+		Shape:   ena.Shape,
+		Data:    jsonData(ena.Data),
+		Strides: ena.Strides,
+		Inf:     inf,
+		NaN:     nan,
+	})
+}
+
+// UnmarshalJSON implements the json.Unarshaller interface.
+// The custom unmarshaller is needed to decode Inf/NaN values.
+func (na *NArray[T]) UnmarshalJSON(b []byte) error {
+	x := struct {
+		Rank    int             `json:"rank"`
+		Shape   []int           `json:"shape"`
+		Data    json.RawMessage `json:"data"`
+		Strides []int           `json:"strides"`
+		Inf     []int           `json:"inf,omitempty"`
+		NaN     []int           `json:"nan,omitempty"`
+	}{}
+
+	err := json.Unmarshal(b, &x)
+	if err != nil {
+		return err
+	}
+	data, err := unmarshalData[T](x.Data)
+	if err != nil {
+		return err
+	}
+
+	na.Rank = x.Rank
+	na.Shape = x.Shape
+	na.Data = data
+	na.Strides = x.Strides
+	na.Decode(x.Inf, x.NaN)
+	return nil
+}
+
+// String prints the narray
+func (na *NArray[T]) String() string {
+
+	return na.Sprint(func(na *NArray[T], k int) bool {
+		return true
+	})
+}
+
+// equal returns true if |x-y|/(|avg(x,y)|+1) < tol.
+func equal[F Float](x, y F, tol float64) bool {
+	avg := (math.Abs(float64(x+y)) / 2.0)
+	sErr := math.Abs(float64(x-y)) / (avg + 1)
+	if sErr > tol {
+		return false
+	}
+	return true
+}
+
+// EqualValues compares two narrays elementwise.
+// Returns true if for all elements |x-y|/(|avg(x,y)|+1) < tol.
+// Integer narrays are compared exactly and tol is ignored.
+func EqualValues[T Elem](x *NArray[T], y *NArray[T], tol float64) bool {
+	if !EqualShape(x, y) {
+		panic(shapeError("EqualValues", "narrays must have equal shape", x, y))
+	}
+	xd := x.Contiguous().data()
+	yd := y.Contiguous().data()
+	k := kindOf[T]()
+	switch {
+	case k.isInteger():
+		for i := range xd {
+			if xd[i] != yd[i] {
+				return false
+			}
+		}
+		return true
+	case k.is64():
+		return equalSlices(f64(xd), f64(yd), tol)
+	}
+	return equalSlices(f32(xd), f32(yd), tol)
+}
+
+func equalSlices[F Float](x, y []F, tol float64) bool {
+	for i := range x {
+		if !equal(x[i], y[i], tol) {
+			return false
+		}
+	}
+	return true
+}
+
+// data returns the segment of Data that holds the elements of a contiguous narray.
+func (na *NArray[T]) data() []T {
+	return na.Data[na.Offset : na.Offset+na.Size()]
+}
+
+// walk calls fn for each element of the narray in row-major order.
+// k is the linear index of the element and idx is its position in Data.
+func (na *NArray[T]) walk(fn func(k, idx int)) {
+
+	n := na.Size()
+	if n == 0 {
+		return
+	}
+	if na.IsContiguous() {
+		for k := 0; k < n; k++ {
+			fn(k, na.Offset+k)
+		}
+		return
+	}
+	it := na.Iter()
+	for k := 0; it.Next(); k++ {
+		fn(k, it.Offset())
+	}
+}
+
+// gather copies the elements of the narray in row-major order into dst.
+func (na *NArray[T]) gather(dst []T) {
+
+	if na.IsContiguous() {
+		copy(dst, na.data())
+		return
+	}
+	na.walk(func(k, idx int) {
+		dst[k] = na.Data[idx]
+	})
+}
+
+// scatter copies the values in src into the elements of the narray in row-major order.
+func (na *NArray[T]) scatter(src []T) {
+
+	if na.IsContiguous() {
+		copy(na.data(), src)
+		return
+	}
+	na.walk(func(k, idx int) {
+		na.Data[idx] = src[k]
+	})
+}
+
+// broadcastOut returns out, or a new narray when out is nil, with the
+// shape that results from broadcasting the input shapes.
+// Will panic if the input shapes can't be broadcast or if the shape of out
+// doesn't match.
+func broadcastOut[T Elem](op string, out *NArray[T], in ...*NArray[T]) *NArray[T] {
+
+	if out != nil && EqualShape(out, in...) {
+		return out
+	}
+	shapes := make([][]int, len(in), len(in))
+	for k, v := range in {
+		shapes[k] = v.Shape
+	}
+	shape, err := BroadcastShapes(shapes...)
+	if err != nil {
+		e := err.(*ShapeError)
+		panic(&ShapeError{Op: op, Msg: e.Msg, Shapes: e.Shapes})
+	}
+	if out == nil {
+		return New[T](shape...)
+	}
+	if !EqualShape(out, &NArray[T]{Shape: shape}) {
+		panic(&ShapeError{Op: op, Msg: "out shape doesn't match broadcast shape", Shapes: [][]int{out.Shape, shape}})
+	}
+	return out
+}
+
+// unaryOp applies a slice kernel to narrays of equal shape.
+// The kernel runs directly on Data when the narrays are contiguous, strided
+// views are gathered into contiguous buffers and the result is scattered back.
+func unaryOp[T Elem](kernel func(out, a []T), out, a *NArray[T]) {
+
+	o := out.Contiguous()
+	kernel(o.data(), a.Contiguous().data())
+	if o != out {
+		out.scatter(o.Data)
+	}
+}
+
+// binaryOp applies a slice kernel to narrays. Inputs whose shape differs
+// from the shape of out are broadcast. See unaryOp for details.
+func binaryOp[T Elem](kernel func(out, a, b []T), out, a, b *NArray[T]) {
+
+	if !EqualShape(out, a) {
+		a = a.Broadcast(out.Shape...)
+	}
+	if !EqualShape(out, b) {
+		b = b.Broadcast(out.Shape...)
+	}
+	o := out.Contiguous()
+	kernel(o.data(), a.Contiguous().data(), b.Contiguous().data())
+	if o != out {
+		out.scatter(o.Data)
+	}
+}
+
+func formatted(n, max int) string {
+	b := bytes.NewBufferString(" ")
+	for i := 0; i < nd(max)-nd(n); i++ {
+		_, _ = b.WriteString(" ")
+	}
+	_, _ = b.WriteString(strconv.FormatInt(int64(n), 10))
+	return b.String()
+}
+
+// num digits in number
+func nd(n int) int {
+	if n == 0 {
+		return 1
+	}
+	return int(math.Log10(float64(n))) + 1
+}
@@ -0,0 +1,266 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// The functions of this package are tested through packages na32 and na64.
+// The tests here cover the helpers and check that the kernels that are
+// dispatched for each element type agree with the Go fallbacks.
+
+func TestKernels64(t *testing.T) {
+	testKernels[float64](t)
+}
+
+func TestKernels32(t *testing.T) {
+	testKernels[float32](t)
+}
+
+// myFloat checks that types with float64 as underlying type use the kernels too.
+type myFloat float64
+
+func TestKernelsUnderlying(t *testing.T) {
+	testKernels[myFloat](t)
+}
+
+func testKernels[T Float](t *testing.T) {
+
+	r := rand.New(rand.NewSource(99))
+	// Odd size to exercise the tail of the unrolled loops.
+	const n = 37
+	a := Norm[T](r, 0, 10, n).Data
+	b := Norm[T](r, 0, 10, n).Data
+	c := T(1.5)
+
+	binary := []struct {
+		name     string
+		fn, fnGo func(out, a, b []T)
+	}{
+		{"div", divSlice[T], divSliceGo[T]},
+		{"add", addSlice[T], addSliceGo[T]},
+		{"sub", subSlice[T], subSliceGo[T]},
+		{"mul", mulSlice[T], mulSliceGo[T]},
+		{"min", minSlice[T], minSliceGo[T]},
+		{"max", maxSlice[T], maxSliceGo[T]},
+		{"csign", csignSlice[T], csignSliceGo[T]},
+	}
+	for _, k := range binary {
+		out := make([]T, n, n)
+		exp := make([]T, n, n)
+		k.fn(out, a, b)
+		k.fnGo(exp, a, b)
+		checkSlice(t, k.name, out, exp)
+	}
+
+	scalar := []struct {
+		name     string
+		fn, fnGo func(out, a []T, c T)
+	}{
+		{"cdiv", cdivSlice[T], cdivSliceGo[T]},
+		{"cmul", cmulSlice[T], cmulSliceGo[T]},
+		{"cadd", caddSlice[T], caddSliceGo[T]},
+	}
+	for _, k := range scalar {
+		out := make([]T, n, n)
+		exp := make([]T, n, n)
+		k.fn(out, a, c)
+		k.fnGo(exp, a, c)
+		checkSlice(t, k.name, out, exp)
+	}
+
+	out := make([]T, n, n)
+	exp := make([]T, n, n)
+	copy(out, b)
+	copy(exp, b)
+	addScaledSlice(out, a, c)
+	addScaledSliceGo(exp, a, c)
+	checkSlice(t, "addScaled", out, exp)
+
+	unary := []struct {
+		name     string
+		fn, fnGo func(out, a []T)
+	}{
+		{"sqrt", sqrtSlice[T], sqrtSliceGo[T]},
+		{"abs", absSlice[T], absSliceGo[T]},
+	}
+	abs := make([]T, n, n)
+	absSliceGo(abs, a)
+	for _, k := range unary {
+		out := make([]T, n, n)
+		exp := make([]T, n, n)
+		k.fn(out, abs)
+		k.fnGo(exp, abs)
+		checkSlice(t, k.name, out, exp)
+	}
+
+	reductions := []struct {
+		name     string
+		fn, fnGo func(a []T) T
+	}{
+		{"minElement", minSliceElement[T], minSliceElementGo[T]},
+		{"maxElement", maxSliceElement[T], maxSliceElementGo[T]},
+		{"sum", sliceSum[T], sliceSumGo[T]},
+	}
+	for _, k := range reductions {
+		checkSlice(t, k.name, []T{k.fn(a)}, []T{k.fnGo(a)})
+	}
+}
+
+func checkSlice[T Float](t *testing.T, name string, out, exp []T) {
+	for i := range exp {
+		if !equal(out[i], exp[i], 0.0001) {
+			t.Fatalf("%s: element %d mismatch, expected %f, got %f", name, i, exp[i], out[i])
+		}
+	}
+}
+
+type myComplex complex64
+
+type myInt uint32
+
+func TestKindOf(t *testing.T) {
+
+	for _, c := range []struct {
+		got, exp kind
+	}{
+		{kindOf[float32](), float32Kind},
+		{kindOf[float64](), float64Kind},
+		{kindOf[myFloat](), float64Kind},
+		{kindOf[complex64](), complex64Kind},
+		{kindOf[complex128](), complex128Kind},
+		{kindOf[myComplex](), complex64Kind},
+		{kindOf[int16](), int16Kind},
+		{kindOf[uint8](), uint8Kind},
+		{kindOf[myInt](), uint32Kind},
+	} {
+		if c.got != c.exp {
+			t.Fatalf("expected kind %d, got %d", c.exp, c.got)
+		}
+	}
+}
+
+func TestFloat64Of(t *testing.T) {
+
+	if v := float64Of(myInt(7)); v != 7 {
+		t.Fatalf("expected 7, got %f", v)
+	}
+	if v := float64Of(myComplex(complex(1.5, 2))); v != 1.5 {
+		t.Fatalf("expected 1.5, got %f", v)
+	}
+	if v := float64Of(int8(-3)); v != -3 {
+		t.Fatalf("expected -3, got %f", v)
+	}
+	if v := fromFloat64[myInt](6.6); v != 7 {
+		t.Fatalf("expected 7, got %d", v)
+	}
+	if v := fromFloat64[int16](-2.5); v != -3 {
+		t.Fatalf("expected -3, got %d", v)
+	}
+	if v := fromFloat64[myComplex](0.5); v != complex(0.5, 0) {
+		t.Fatalf("expected (0.5+0i), got %v", v)
+	}
+	if v := fromFloat64[myFloat](0.25); v != 0.25 {
+		t.Fatalf("expected 0.25, got %f", v)
+	}
+
+	// Out of range values are clamped and NaN is zero.
+	if v := fromFloat64[int8](300); v != math.MaxInt8 {
+		t.Fatalf("expected %d, got %d", math.MaxInt8, v)
+	}
+	if v := fromFloat64[uint8](-1); v != 0 {
+		t.Fatalf("expected 0, got %d", v)
+	}
+	if v := fromFloat64[myInt](math.Inf(1)); v != math.MaxUint32 {
+		t.Fatalf("expected %d, got %d", uint32(math.MaxUint32), v)
+	}
+	if v := fromFloat64[int64](math.Inf(-1)); v != math.MinInt64 {
+		t.Fatalf("expected %d, got %d", math.MinInt64, v)
+	}
+	if v := fromFloat64[int64](1e300); v != int64(maxInt64) {
+		t.Fatalf("expected %d, got %d", int64(maxInt64), v)
+	}
+	if v := fromFloat64[uint64](1e300); v != uint64(maxUint64) {
+		t.Fatalf("expected %d, got %d", uint64(maxUint64), v)
+	}
+	if v := fromFloat64[int32](math.NaN()); v != 0 {
+		t.Fatalf("expected 0, got %d", v)
+	}
+}
+
+func TestConvertValues(t *testing.T) {
+
+	i8 := make([]int8, 4, 4)
+	if err := convertValues(i8, []float64{300, -1.6, math.NaN(), math.Inf(-1)}); err != nil {
+		t.Fatal(err)
+	}
+	if i8[0] != math.MaxInt8 || i8[1] != -2 || i8[2] != 0 || i8[3] != math.MinInt8 {
+		t.Fatalf("unexpected values %v", i8)
+	}
+	i16 := make([]int16, 2, 2)
+	if err := convertValues(i16, []uint16{65535, 7}); err != nil || i16[0] != -1 || i16[1] != 7 {
+		t.Fatalf("unexpected values %v, %v", i16, err)
+	}
+	c := make([]myComplex, 2, 2)
+	if err := convertValues(c, []bool{true, false}); err != nil || c[0] != 1 || c[1] != 0 {
+		t.Fatalf("unexpected values %v, %v", c, err)
+	}
+	if err := convertValues(make([]float32, 1, 1), []complex64{1}); err == nil {
+		t.Fatalf("expected an error for complex values")
+	}
+}
+
+func TestComplexKernels(t *testing.T) {
+
+	a := []complex128{1 + 2i, -3 + 1i, 0.5i}
+	b := []complex128{2 - 1i, 1i, -1}
+	out := make([]complex128, 3, 3)
+	exp := make([]complex128, 3, 3)
+	addSlice(out, a, b)
+	addSliceGo(exp, a, b)
+	if out[0] != exp[0] || out[1] != exp[1] || out[2] != exp[2] {
+		t.Fatalf("add: expected %v, got %v", exp, out)
+	}
+	mulSlice(out, a, b)
+	mulSliceGo(exp, a, b)
+	if out[0] != exp[0] || out[1] != exp[1] || out[2] != exp[2] {
+		t.Fatalf("mul: expected %v, got %v", exp, out)
+	}
+	if s := sliceSum(a); s != -2+3.5i {
+		t.Fatalf("sum: expected %v, got %v", -2+3.5i, s)
+	}
+	if !less(a[1], a[2]) || less(a[2], a[1]) || less(a[0], a[0]) {
+		t.Fatalf("unexpected order for %v", a)
+	}
+	if i := argMaxSlice(a); i != 0 {
+		t.Fatalf("expected max at 0, got %d", i)
+	}
+}
+
+func TestIntegerOrder(t *testing.T) {
+
+	a := []int16{3, -7, 12, -7, 12}
+	if i := argMaxSlice(a); i != 2 {
+		t.Fatalf("expected max at 2, got %d", i)
+	}
+	if i := argMinSlice(a); i != 1 {
+		t.Fatalf("expected min at 1, got %d", i)
+	}
+	// Large unsigned values must not be compared as signed.
+	b := []myInt{1, 1 << 31, 5}
+	if i := argMaxSlice(b); i != 1 {
+		t.Fatalf("expected max at 1, got %d", i)
+	}
+	if !less(myInt(5), myInt(1<<31)) || less(uint64(1<<63), uint64(1)) {
+		t.Fatalf("unexpected order for unsigned values")
+	}
+	if s := sliceSum(a); s != 13 {
+		t.Fatalf("sum: expected 13, got %d", s)
+	}
+}
@@ -6,10 +6,14 @@
 package {{.Package}}
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
 	"math"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -201,22 +205,10 @@ func checkVector(t *testing.T, vec *Vector, query ...int) {
 
 	sa := na234.SubArray(query...)
 	if !EqualValues((*NArray)(vec), sa, 0.0001) {
-		t.Fatalf("vec values dont' match expected:%s, got:%s", vec, sa)
+		t.Fatalf("vec values dont' match expected:%s, got:%s", sa, (*NArray)(vec))
 	}
 }
 
-func TestCartesianProduct(t *testing.T) {
-
-	cp := cartesianProduct([]int{2, 3, 4})
-	t.Log(cp)
-}
-
-func TestQuerySubset(t *testing.T) {
-
-	qs := querySubset([]int{-1, -1, 1}, []int{2, 3, 4})
-	t.Log(qs)
-}
-
 func TestSubArray(t *testing.T) {
 	sa := na234.SubArray(-1, -1, 1)
 	t.Log(sa)
@@ -258,6 +250,161 @@ func TestSubArray(t *testing.T) {
 			}
 		}
 	}
+	if !panics(func() { na234.SubArray(0, 3, -1) }) {
+		t.Fatalf("expected panic for an index out of range")
+	}
+	if !panics(func() { na234.SubArray(-1, -1, 4) }) {
+		t.Fatalf("expected panic for an index out of range")
+	}
+}
+
+func TestSubArrayView(t *testing.T) {
+
+	na := na234.Copy()
+	sa := na.SubArray(1, -1, 2)
+	if sa.IsContiguous() {
+		t.Fatalf("expected non-contiguous view")
+	}
+	sa.Set(-1, 1)
+	if na.At(1, 1, 2) != -1 {
+		t.Fatalf("expected -1, got %f", na.At(1, 1, 2))
+	}
+
+	// A contiguous view shares data with a non-zero offset.
+	row := na.SubArray(1, 2, -1)
+	if !row.IsContiguous() || row.Offset != 20 {
+		t.Fatalf("expected contiguous view with offset 20, got offset %d", row.Offset)
+	}
+
+	// Operations on views.
+	cp := sa.Copy()
+	if !cp.IsContiguous() || cp.Offset != 0 || len(cp.Data) != 3 {
+		t.Fatalf("expected compact copy, got %v", cp)
+	}
+	out := Add(nil, sa, cp)
+	for j := 0; j < 3; j++ {
+		if out.At(j) != 2*na.At(1, j, 2) {
+			t.Fatalf("expected %f, got %f", 2*na.At(1, j, 2), out.At(j))
+		}
+	}
+	Scale(sa, sa, 0)
+	if sa.Sum() != 0 || na.At(1, 0, 2) != 0 || na.At(1, 0, 1) != na234.At(1, 0, 1) {
+		t.Fatalf("scale on view failed")
+	}
+	if na.SubArray(0, -1, -1).Max() != na234.At(0, 2, 3) {
+		t.Fatalf("max on view failed")
+	}
+	if Sqrt(nil, na.SubArray(-1, 0, 0)).At(1) != {{.Format}}(math.Sqrt(float64(na234.At(1, 0, 0)))) {
+		t.Fatalf("sqrt on view failed")
+	}
+
+	// Reshape of a non-contiguous view is a copy.
+	rs := na.SubArray(-1, -1, 3).Reshape(-1)
+	if rs.At(4) != na.At(1, 1, 3) {
+		t.Fatalf("expected %f, got %f", na.At(1, 1, 3), rs.At(4))
+	}
+}
+
+func TestReshape(t *testing.T) {
+
+	na := na234.Reshape(6, 4)
+	if na.Rank != 2 || na.Shape[0] != 6 || na.Shape[1] != 4 {
+		t.Fatalf("expected shape [6 4], got %v", na.Shape)
+	}
+	if na.Strides[0] != 4 || na.Strides[1] != 1 {
+		t.Fatalf("expected strides [4 1], got %v", na.Strides)
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 4; k++ {
+				if na234.At(i, j, k) != na.At(i*3+j, k) {
+					t.Fatalf("element mismatch got %f expected %f", na.At(i*3+j, k), na234.At(i, j, k))
+				}
+			}
+		}
+	}
+
+	// Infer dimension.
+	vec := na234.Reshape(-1)
+	if vec.Rank != 1 || vec.Shape[0] != 24 {
+		t.Fatalf("expected shape [24], got %v", vec.Shape)
+	}
+	mat := vec.Reshape(2, -1, 3)
+	if mat.Shape[1] != 4 {
+		t.Fatalf("expected inferred dim 4, got %d", mat.Shape[1])
+	}
+
+	// Data is shared.
+	xx := x.Copy()
+	yy := xx.Reshape(5, 3)
+	yy.Set(-1, 4, 2)
+	if xx.At(2, 4) != -1 {
+		t.Fatalf("expected -1, got %f", xx.At(2, 4))
+	}
+
+	if !panics(func() { na234.Reshape(5, 5) }) {
+		t.Errorf("did not panic with size mismatch")
+	}
+	if !panics(func() { na234.Reshape(-1, -1) }) {
+		t.Errorf("did not panic with two inferred dimensions")
+	}
+	if !panics(func() { na234.Reshape(5, -1) }) {
+		t.Errorf("did not panic with invalid inferred dimension")
+	}
+}
+
+func TestPermute(t *testing.T) {
+
+	p := na234.Permute(2, 0, 1)
+	if p.Shape[0] != 4 || p.Shape[1] != 2 || p.Shape[2] != 3 {
+		t.Fatalf("expected shape [4 2 3], got %v", p.Shape)
+	}
+	c := p.Copy()
+	if !c.IsContiguous() {
+		t.Fatalf("expected contiguous copy")
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 4; k++ {
+				if p.At(k, i, j) != na234.At(i, j, k) || c.At(k, i, j) != na234.At(i, j, k) {
+					t.Fatalf("element mismatch got %f expected %f", p.At(k, i, j), na234.At(i, j, k))
+				}
+			}
+		}
+	}
+
+	// Permuting back gives the original layout.
+	if !p.Permute(1, 2, 0).IsContiguous() {
+		t.Fatalf("expected contiguous view")
+	}
+
+	if !panics(func() { na234.Permute(0, 1) }) {
+		t.Errorf("did not panic with wrong number of axes")
+	}
+	if !panics(func() { na234.Permute(0, 1, 1) }) {
+		t.Errorf("did not panic with repeated axis")
+	}
+}
+
+func TestTranspose(t *testing.T) {
+
+	tr := x.Transpose()
+	if tr.Shape[0] != 5 || tr.Shape[1] != 3 {
+		t.Fatalf("expected shape [5 3], got %v", tr.Shape)
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 5; j++ {
+			if tr.At(j, i) != x.At(i, j) {
+				t.Fatalf("element mismatch got %f expected %f", tr.At(j, i), x.At(i, j))
+			}
+		}
+	}
+	if !EqualValues(Add(nil, tr, tr), Scale(nil, tr.Copy(), 2), 0) {
+		t.Fatalf("expected same values")
+	}
+	if !panics(func() { na234.Transpose() }) {
+		t.Errorf("did not panic with rank 3")
+	}
 }
 
 func TestScalar(t *testing.T) {
@@ -405,6 +552,86 @@ func TestAdd(t *testing.T) {
 	}
 }
 
+func TestBroadcastShapes(t *testing.T) {
+
+	cases := []struct {
+		shapes [][]int
+		result []int
+	}{
+		{[][]int{[]int{3, 5}, []int{3, 5}}, []int{3, 5}},
+		{[][]int{[]int{3, 5}, []int{5}}, []int{3, 5}},
+		{[][]int{[]int{3, 1}, []int{1, 5}}, []int{3, 5}},
+		{[][]int{[]int{15, 3, 5}, []int{3, 1}}, []int{15, 3, 5}},
+		{[][]int{[]int{2, 1, 4}, []int{3, 1}, []int{}}, []int{2, 3, 4}},
+	}
+	for _, c := range cases {
+		res, err := BroadcastShapes(c.shapes...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !EqualShape(&NArray{Shape: res}, &NArray{Shape: c.result}) {
+			t.Fatalf("expected %v, got %v", c.result, res)
+		}
+	}
+	if _, err := BroadcastShapes([]int{3, 5}, []int{3}); err == nil {
+		t.Fatalf("expected error for incompatible shapes")
+	}
+}
+
+func TestBroadcast(t *testing.T) {
+
+	// Subtract row vector from matrix.
+	mean := New(5)
+	for j := 0; j < 5; j++ {
+		mean.Set({{.Format}}(j), j)
+	}
+	z := Sub(nil, x, mean)
+	if !EqualShape(z, x) {
+		t.Fatalf("expected shape %v, got %v", x.Shape, z.Shape)
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 5; j++ {
+			if z.At(i, j) != x.At(i, j)-mean.At(j) {
+				t.Fatalf("expected %f, got %f", x.At(i, j)-mean.At(j), z.At(i, j))
+			}
+		}
+	}
+
+	// Column vector times row vector.
+	col := NewArray([]{{.Format}}{1, 2, 3}, 3, 1)
+	row := NewArray([]{{.Format}}{1, 10}, 2)
+	outer := Mul(nil, col, row)
+	if outer.Rank != 2 || outer.Shape[0] != 3 || outer.Shape[1] != 2 {
+		t.Fatalf("expected shape [3 2], got %v", outer.Shape)
+	}
+	if outer.At(2, 1) != 30 {
+		t.Fatalf("expected 30, got %f", outer.At(2, 1))
+	}
+
+	// Scalar narray.
+	two := New()
+	two.Set(2)
+	if !EqualValues(Div(nil, x, two), Scale(nil, x, 0.5), 0) {
+		t.Fatalf("expected same values")
+	}
+	if Copysign(nil, x, NewArray([]{{.Format}}{-1}, 1)).Max() != 0 {
+		t.Fatalf("expected non-positive values")
+	}
+
+	// Generated binary functions.
+	p := Pow(nil, x, NewArray([]{{.Format}}{2}, 1))
+	if p.At(2, 3) != x.At(2, 3)*x.At(2, 3) {
+		t.Fatalf("expected %f, got %f", x.At(2, 3)*x.At(2, 3), p.At(2, 3))
+	}
+
+	if !panics(func() { Add(nil, x, New(3)) }) {
+		t.Errorf("did not panic with incompatible shapes")
+	}
+	if !panics(func() { Add(New(5), x, mean) }) {
+		t.Errorf("did not panic with wrong out shape")
+	}
+}
+
 func TestAddConst(t *testing.T) {
 
 	out := AddConst(nil, randna[0], 2.0)
@@ -719,6 +946,395 @@ func TestEncode(t *testing.T) {
 	}
 }
 
+// npyFile returns the bytes of a .npy file with a version 2.0 header.
+func npyFile(dict string, order binary.ByteOrder, data interface{}) []byte {
+	var b bytes.Buffer
+	b.WriteString("\x93NUMPY\x02\x00")
+	binary.Write(&b, binary.LittleEndian, uint32(len(dict)+1))
+	b.WriteString(dict + "\n")
+	binary.Write(&b, order, data)
+	return b.Bytes()
+}
+
+func TestNPY(t *testing.T) {
+
+	// A strided view is written in C order.
+	var buf bytes.Buffer
+	xt := x.Transpose()
+	if err := xt.WriteNPY(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var v {{.Format}}
+	if hlen := buf.Len() - xt.Size()*int(binary.Size(v)); hlen%64 != 0 {
+		t.Fatalf("header length [%d] is not a multiple of 64", hlen)
+	}
+	x1, err := ReadNPY(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !EqualValues(xt, x1, 0) {
+		t.Fatalf("expected %v, got %v", xt, x1)
+	}
+
+	// Files written by NumPy, float32 and int16 values are converted.
+	c := NewArray([]{{.Format}}{0, 1, 2, 3, 4, 5}, 2, 3)
+	f := NewArray([]{{.Format}}{0, 2, 4, 1, 3, 5}, 2, 3)
+	for fn, exp := range map[string]*NArray{
+		"data_float32_2x3_corder.npy": c,
+		"data_float64_2x3_forder.npy": f,
+		"data_int16_2x3_corder.npy":   c,
+		"data_int32_scalar_corder.npy": NewArray([]{{.Format}}{42}),
+	} {
+		x1, err := ReadNPYFile(filepath.Join("..", "testdata", "npy", fn))
+		if err != nil || !EqualShape(x1, exp) || !EqualValues(x1, exp, 0) {
+			t.Fatalf("%s: expected %v, got %v, %v", fn, exp, x1, err)
+		}
+	}
+
+	// Malformed files.
+	for _, f := range [][]byte{
+		npyFile("{'descr': '<c16', 'fortran_order': False, 'shape': (1,), }",
+			binary.LittleEndian, []complex128{1}),
+		npyFile("{'descr': [('a', '<f8')], 'fortran_order': False, 'shape': (1,), }",
+			binary.LittleEndian, []float64{1}),
+		npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (4,), }",
+			binary.LittleEndian, []float64{1}),
+		npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (4611686018427387904, 4), }",
+			binary.LittleEndian, []float64{1}),
+		npyFile("{'descr': '<f8', 'fortran_order': False, 'shape': (200000000, 4), }",
+			binary.LittleEndian, []float64{1}),
+		[]byte("\x93NUMPZ\x01\x00"),
+	} {
+		if _, err := ReadNPY(bytes.NewReader(f)); err == nil {
+			t.Fatalf("expected an error reading %q", f)
+		}
+	}
+}
+
+func TestNPZ(t *testing.T) {
+
+	// A file written by NumPy.
+	ref, err := ReadNPZFile(filepath.Join("..", "testdata", "npy", "data_float64_forder.npz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	arr0 := NewArray([]{{.Format}}{0, 2, 4, 1, 3, 5}, 2, 3)
+	arr1 := NewArray([]{{.Format}}{0, 1, 2, 3, 4, 5}, 6, 1)
+	if len(ref) != 2 || !EqualShape(ref["arr0"], arr0) || !EqualValues(ref["arr0"], arr0, 0) ||
+		!EqualShape(ref["arr1"], arr1) || !EqualValues(ref["arr1"], arr1, 0) {
+		t.Fatalf("expected arr0 %v and arr1 %v, got %v", arr0, arr1, ref)
+	}
+
+	arrays := map[string]*NArray{"x": x, "na234": na234}
+	for _, compress := range []bool{false, true} {
+		fn := filepath.Join(os.TempDir(), "narray.npz")
+		if err := WriteNPZFile(fn, arrays, compress); err != nil {
+			t.Fatal(err)
+		}
+		res, err := ReadNPZFile(fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res) != 2 || !EqualValues(res["x"], x, 0) || !EqualValues(res["na234"], na234, 0) {
+			t.Fatalf("expected %v, got %v", arrays, res)
+		}
+	}
+}
+
+func TestBinary(t *testing.T) {
+
+	// Inf and NaN values of a strided view.
+	xx := x.Copy()
+	xx.Set({{.Format}}(math.Inf(-1)), 1, 1)
+	xx.Set({{.Format}}(math.NaN()), 0, 2)
+	b, err := xx.Transpose().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var x1 NArray
+	if err := x1.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !EqualShape(&x1, x.Transpose()) {
+		t.Fatalf("expected shape %v, got %v", x.Transpose().Shape, x1.Shape)
+	}
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 3; j++ {
+			v := float64(x1.At(i, j))
+			switch {
+			case i == 1 && j == 1:
+				if !math.IsInf(v, -1) {
+					t.Fatalf("expected -Inf, got %v", v)
+				}
+			case i == 2 && j == 0:
+				if !math.IsNaN(v) {
+					t.Fatalf("expected NaN, got %v", v)
+				}
+			case x1.At(i, j) != x.At(j, i):
+				t.Fatalf("expected %v at [%d %d], got %v", x.At(j, i), i, j, v)
+			}
+		}
+	}
+	if err := x1.UnmarshalBinary(append(b, 0)); err == nil {
+		t.Fatalf("expected an error for trailing bytes")
+	}
+	if err := x1.UnmarshalBinary(b[:len(b)-1]); err == nil {
+		t.Fatalf("expected an error for missing bytes")
+	}
+	if err := x1.UnmarshalBinary([]byte("NARX\x01f")); err == nil {
+		t.Fatalf("expected an error for an invalid magic string")
+	}
+	// Shapes larger than the input are errors, not allocations.
+	for _, d := range []uint64{1 << 61, 1 << 40, 200000000} {
+		h := append([]byte("NARY\x01f\x08\x01"), binary.LittleEndian.AppendUint64(nil, d)...)
+		if err := x1.UnmarshalBinary(h); err == nil {
+			t.Fatalf("expected an error for [%d] values", d)
+		}
+		if _, err := x1.ReadFrom(bytes.NewReader(append(h, 0, 0, 0, 0, 0, 0, 0, 0))); err == nil {
+			t.Fatalf("expected an error for [%d] values", d)
+		}
+	}
+
+	// A stream of narrays.
+	var buf bytes.Buffer
+	n1, err := x.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n2, err := na234.WriteTo(&buf)
+	if err != nil || n1+n2 != int64(buf.Len()) {
+		t.Fatalf("expected [%d] bytes, got [%d], %v", buf.Len(), n1+n2, err)
+	}
+	var y1, y2 NArray
+	if _, err := y1.ReadFrom(&buf); err != nil || !EqualValues(&y1, x, 0) {
+		t.Fatalf("expected %v, got %v, %v", x, &y1, err)
+	}
+	if _, err := y2.ReadFrom(&buf); err != nil || !EqualValues(&y2, na234, 0) {
+		t.Fatalf("expected %v, got %v, %v", na234, &y2, err)
+	}
+
+	// Gob uses the binary encoding.
+	type model struct {
+		Name string
+		Mean *NArray
+	}
+	buf.Reset()
+	if err := gob.NewEncoder(&buf).Encode(model{Name: "m", Mean: na234}); err != nil {
+		t.Fatal(err)
+	}
+	var m model
+	if err := gob.NewDecoder(&buf).Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Name != "m" || !EqualValues(m.Mean, na234, 0) {
+		t.Fatalf("expected %v, got %v", na234, m.Mean)
+	}
+}
+
+// htkFile returns the bytes of an HTK file with float32 frames, for malformed files.
+func htkFile(kind HTKKind, dims int, values []float32) []byte {
+	var b bytes.Buffer
+	binary.Write(&b, binary.BigEndian, []int32{int32(len(values) / dims), 100000})
+	binary.Write(&b, binary.BigEndian, []uint16{uint16(dims * 4), uint16(kind)})
+	binary.Write(&b, binary.BigEndian, values)
+	return b.Bytes()
+}
+
+func TestHTK(t *testing.T) {
+
+	kind, err := ParseHTKKind("MFCC_E_D_A_Z_0")
+	if err != nil || kind != HTKMFCC|HTKEnergy|HTKDelta|HTKAccel|HTKZeroMean|HTKZeroth || kind.String() != "MFCC_E_D_A_Z_0" {
+		t.Fatalf("expected MFCC_E_D_A_Z_0, got %s, %v", kind, err)
+	}
+	if _, err := ParseHTKKind("MFCC_Q"); err == nil {
+		t.Fatalf("expected an error for an unknown qualifier")
+	}
+
+	// The reference files, plain, compressed and with a checksum, read
+	// the expected values and write back to the same bytes.
+	for _, tt := range []struct {
+		fn   string
+		kind HTKKind
+		exp  *NArray
+	}{
+		{"mfcc_e.htk", HTKMFCC | HTKEnergy, NewArray([]{{.Format}}{
+			1.5, -2.25, 0, 10, 0.125, 3, -4.5, 7.75, -1, 0.5, 2, -0.0625}, 3, 4)},
+		{"mfcc_0_c.htk", HTKMFCC | HTKZeroth | HTKCompressed, NewArray([]{{.Format}}{
+			-127.99609375, -126.99609375, 0.5, 1.5, 127.99609375, 128.99609375}, 3, 2)},
+		{"mfcc_e_k.htk", HTKMFCC | HTKEnergy | HTKCRC, NewArray([]{{.Format}}{
+			0.5, 1, 1.5, -2, -2.5, -3}, 2, 3)},
+	} {
+		f, err := os.ReadFile(filepath.Join("..", "testdata", "htk", tt.fn))
+		if err != nil {
+			t.Fatal(err)
+		}
+		x1, hdr, err := ReadHTK(bytes.NewReader(f))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.fn, err)
+		}
+		if hdr.SampPeriod != 100000 || hdr.Kind != tt.kind || !EqualShape(x1, tt.exp) || !EqualValues(x1, tt.exp, 0) {
+			t.Fatalf("%s: expected %v of kind %s, got %v of kind %s", tt.fn, tt.exp, tt.kind, x1, hdr.Kind)
+		}
+		var buf bytes.Buffer
+		if err := WriteHTK(&buf, x1, hdr); err != nil || !bytes.Equal(buf.Bytes(), f) {
+			t.Fatalf("%s: expected %q, got %q, %v", tt.fn, f, buf.Bytes(), err)
+		}
+	}
+
+	// Compressed with a checksum.
+	r := rand.New(rand.NewSource(99))
+	feats := Norm(r, 0, 10, 50, 13)
+	feats.SubArray(-1, 4).SetValue(7)
+	hdr := HTKHeader{SampPeriod: 100000, Kind: HTKMFCC | HTKCompressed | HTKCRC}
+	var buf bytes.Buffer
+	if err := WriteHTK(&buf, feats, hdr); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 12+54*13*2+2 {
+		t.Fatalf("expected [%d] bytes, got [%d]", 12+54*13*2+2, buf.Len())
+	}
+	b := buf.Bytes()
+	x1, h, err := ReadHTK(bytes.NewReader(b))
+	if err != nil || h != hdr {
+		t.Fatalf("expected header %v, got %v, %v", hdr, h, err)
+	}
+	if !EqualValues(x1, feats, 0.01) || x1.At(10, 4) != 7 {
+		t.Fatalf("expected %v, got %v", feats, x1)
+	}
+	b[20]++
+	if _, _, err := ReadHTK(bytes.NewReader(b)); err == nil {
+		t.Fatalf("expected a CRC error")
+	}
+	f := htkFile(HTKMFCC|HTKZeroth, 3, []float32{1, -2.5, 3, 4, 5, 6e-3})
+	if _, _, err := ReadHTK(bytes.NewReader(f[:len(f)-1])); err == nil {
+		t.Fatalf("expected an error for a truncated file")
+	}
+	// A header with more samples than the file.
+	binary.BigEndian.PutUint32(f, 1<<30)
+	if _, _, err := ReadHTK(bytes.NewReader(f)); err == nil {
+		t.Fatalf("expected an error for a truncated file")
+	}
+	if !panics(func() { WriteHTK(&buf, na234, hdr) }) {
+		t.Fatalf("expected panic for an narray of rank three")
+	}
+
+	// Waveforms are clamped to the range of int16.
+	wav := NewArray([]{{.Format}}{40000, -40000, 1.4, -2.6}, 4, 1)
+	buf.Reset()
+	if err := WriteHTK(&buf, wav, HTKHeader{SampPeriod: 625, Kind: HTKWaveform}); err != nil {
+		t.Fatal(err)
+	}
+	x1, _, err = ReadHTK(bytes.NewReader(buf.Bytes()))
+	exp := NewArray([]{{.Format}}{32767, -32768, 1, -3}, 4, 1)
+	if err != nil || !EqualValues(x1, exp, 0) {
+		t.Fatalf("expected %v, got %v, %v", exp, x1, err)
+	}
+	nan := feats.Copy()
+	for _, v := range []float64{math.NaN(), math.Inf(1)} {
+		nan.Set({{.Format}}(v), 3, 2)
+		for _, kind := range []HTKKind{HTKWaveform, HTKMFCC | HTKCompressed} {
+			if err := WriteHTK(&buf, nan, HTKHeader{SampPeriod: 100000, Kind: kind}); err == nil {
+				t.Fatalf("expected an error writing %v as %s", v, kind)
+			}
+		}
+	}
+}
+
+// stFile returns the bytes of a safetensors file.
+func stFile(header string, data interface{}) []byte {
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, uint64(len(header)))
+	b.WriteString(header)
+	binary.Write(&b, binary.LittleEndian, data)
+	return b.Bytes()
+}
+
+func TestSafetensors(t *testing.T) {
+
+	fn := filepath.Join(os.TempDir(), "narray.safetensors")
+	arrays := map[string]*NArray{"x": x.Transpose(), "na234": na234}
+	if err := SaveSafetensors(fn, arrays, map[string]string{"format": "np"}); err != nil {
+		t.Fatal(err)
+	}
+	st, err := LoadSafetensors(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+	if names := st.Names(); len(names) != 2 || names[0] != "na234" || names[1] != "x" {
+		t.Fatalf("expected names [na234 x], got %v", names)
+	}
+	// The data of na234 comes first, in order of name.
+	if info, ok := st.Info("x"); !ok || info.Shape[0] != 5 || info.Shape[1] != 3 || info.Offsets[0] != 24*info.Offsets[1]/39 {
+		t.Fatalf("expected tensor x of shape [5 3] after na234, got %v", info)
+	}
+	if st.Metadata["format"] != "np" {
+		t.Fatalf("expected metadata format np, got %v", st.Metadata)
+	}
+	x1, err := st.Get("x")
+	if err != nil || !EqualValues(x1, x.Transpose().Copy(), 0) {
+		t.Fatalf("expected %v, got %v, %v", x.Transpose(), x1, err)
+	}
+	all, err := st.GetAll()
+	if err != nil || len(all) != 2 || !EqualValues(all["na234"], na234, 0) {
+		t.Fatalf("expected %v, got %v, %v", arrays, all, err)
+	}
+	if _, err := st.Get("y"); err == nil {
+		t.Fatalf("expected an error for a missing tensor")
+	}
+
+	// A reference file, half precision and integer values are converted
+	// and F8 values are not supported.
+	ref, err := LoadSafetensors(filepath.Join("..", "testdata", "safetensors", "ref.safetensors"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ref.Close()
+	if names := ref.Names(); len(names) != 5 || ref.Metadata["format"] != "pt" {
+		t.Fatalf("expected 5 tensors and metadata format pt, got %v and %v", names, ref.Metadata)
+	}
+	for name, exp := range map[string]*NArray{
+		"bf16": NewArray([]{{.Format}}{1, -3}, 2),
+		"f16":  NewArray([]{{.Format}}{1, -2, 0.5, {{.Format}}(math.Ldexp(1, -24))}, 4),
+		"f32":  NewArray([]{{.Format}}{0.5, -1, 2.25, 3, -4.5, 1024}, 2, 3),
+		"i64":  NewArray([]{{.Format}}{-7, 42}, 2),
+	} {
+		na, err := ref.Get(name)
+		if err != nil || !EqualShape(na, exp) || !EqualValues(na, exp, 0) {
+			t.Fatalf("%s: expected %v, got %v, %v", name, exp, na, err)
+		}
+	}
+	if _, err := ref.Get("f8"); err == nil {
+		t.Fatalf("expected an error for dtype F8_E4M3")
+	}
+	// The first error in order of name.
+	if _, err := ref.GetAll(); err == nil || !strings.Contains(err.Error(), `"f8"`) {
+		t.Fatalf("expected an error for tensor f8, got %v", err)
+	}
+
+	// Malformed headers, the data of x is 8 bytes.
+	for _, header := range []string{
+		`{"x": {"dtype": "F32", "shape": [3], "data_offsets": [0, 8]}}`,
+		`{"x": {"dtype": "F32", "shape": [2], "data_offsets": [8, 0]}}`,
+		`{"x": [1, 2]}`,
+		`{"x": {"dtype": "F32", "shape": [4], "data_offsets": [0, 16]}}`,
+		`{"x": {"dtype": "F64", "shape": [1000000000000], "data_offsets": [0, 8000000000000]}}`,
+		`{"x": {"dtype": "F32", "shape": [2], "data_offsets": [4611686018427387904, 4611686018427387912]}}`,
+		`{"x": {"dtype": "F32", "shape": [1], "data_offsets": [4, 8]}}`,
+		`{"x": {"dtype": "F32", "shape": [1], "data_offsets": [0, 4]}}`,
+		`{"x": {"dtype": "F32", "shape": [2], "data_offsets": [0, 8]}, "y": {"dtype": "F32", "shape": [1], "data_offsets": [4, 8]}}`,
+	} {
+		f := stFile(header, []float32{1, 2})
+		if _, err := ReadSafetensors(bytes.NewReader(f), int64(len(f))); err == nil {
+			t.Fatalf("expected an error for header %s", header)
+		}
+	}
+	f := stFile("{}", []float32{})
+	if _, err := ReadSafetensors(bytes.NewReader(f), int64(len(f))-1); err == nil {
+		t.Fatalf("expected an error for a header longer than the file")
+	}
+}
+
 func BenchmarkRead(b *testing.B) {
 
 	rank := rand.Intn(10)
@@ -0,0 +1,529 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// The NumPy .npy format is a magic string, a version, the length of the
+// header and a header with the Python literal of a dict, for example
+//
+//	{'descr': '<f8', 'fortran_order': False, 'shape': (3, 4), }
+//
+// followed by the raw data. See numpy.lib.format for the specification.
+const npyMagic = "\x93NUMPY"
+
+// npyTypes maps the dtype codes of the .npy format to Go types.
+var npyTypes = map[string]reflect.Type{
+	"b1":  reflect.TypeOf(false),
+	"i1":  reflect.TypeOf(int8(0)),
+	"i2":  reflect.TypeOf(int16(0)),
+	"i4":  reflect.TypeOf(int32(0)),
+	"i8":  reflect.TypeOf(int64(0)),
+	"u1":  reflect.TypeOf(uint8(0)),
+	"u2":  reflect.TypeOf(uint16(0)),
+	"u4":  reflect.TypeOf(uint32(0)),
+	"u8":  reflect.TypeOf(uint64(0)),
+	"f4":  reflect.TypeOf(float32(0)),
+	"f8":  reflect.TypeOf(float64(0)),
+	"c8":  reflect.TypeOf(complex64(0)),
+	"c16": reflect.TypeOf(complex128(0)),
+}
+
+// npyDescr maps element kinds to the dtype written by WriteNPY.
+var npyDescr = map[kind]string{
+	float32Kind:    "<f4",
+	float64Kind:    "<f8",
+	complex64Kind:  "<c8",
+	complex128Kind: "<c16",
+	int8Kind:       "|i1",
+	int16Kind:      "<i2",
+	int32Kind:      "<i4",
+	int64Kind:      "<i8",
+	uint8Kind:      "|u1",
+	uint16Kind:     "<u2",
+	uint32Kind:     "<u4",
+	uint64Kind:     "<u8",
+}
+
+var (
+	npyDescrRE   = regexp.MustCompile(`['"]descr['"]\s*:\s*['"]([^'"]*)['"]`)
+	npyFortranRE = regexp.MustCompile(`['"]fortran_order['"]\s*:\s*(True|False)`)
+	npyShapeRE   = regexp.MustCompile(`['"]shape['"]\s*:\s*\(([^)]*)\)`)
+)
+
+// ReadNPY reads an narray in the NumPy .npy format. Versions 1.0, 2.0 and
+// 3.0 of the format, both byte orders and both C and Fortran order are
+// supported. The result is always in row-major order.
+//
+// The values are converted to the element type of the result, so that a
+// '<f8' or a '>i4' file can be read into a float32 narray, for example.
+// Floats are rounded and clamped to the range of the type when converted to
+// integers and real values become complex values with a zero imaginary part.
+// Complex values can only be read into complex narrays. Returns an error for
+// structured and object dtypes, and for shapes that don't fit in the input.
+func ReadNPY[T Elem](r io.Reader) (*NArray[T], error) {
+
+	pre := make([]byte, len(npyMagic)+2, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, pre); err != nil {
+		return nil, fmt.Errorf("npy: can't read magic string: %v", err)
+	}
+	if string(pre[:len(npyMagic)]) != npyMagic {
+		return nil, fmt.Errorf("npy: not a .npy file")
+	}
+	var hlen int
+	switch major := pre[len(npyMagic)]; major {
+	case 1:
+		var n uint16
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, fmt.Errorf("npy: can't read header length: %v", err)
+		}
+		hlen = int(n)
+	case 2, 3:
+		var n uint32
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, fmt.Errorf("npy: can't read header length: %v", err)
+		}
+		hlen = int(n)
+	default:
+		return nil, fmt.Errorf("npy: unsupported format version [%d]", major)
+	}
+	header, err := readBounded(r, int64(hlen))
+	if err != nil {
+		return nil, fmt.Errorf("npy: can't read header: %v", err)
+	}
+	typ, order, fortran, shape, err := parseNPYHeader(string(header))
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the data before allocating the narray, so that the memory used
+	// is bounded by the size of the file and not by the header.
+	size := int(typ.Size())
+	for _, d := range shape {
+		if d > 0 && size > math.MaxInt/d {
+			return nil, fmt.Errorf("npy: shape %v is too large", shape)
+		}
+		size *= d
+	}
+	data, err := readBounded(r, int64(size))
+	if err != nil {
+		return nil, fmt.Errorf("npy: can't read data: %v", err)
+	}
+
+	// Fortran order is row-major order of the reversed shape.
+	dims := shape
+	if fortran {
+		dims = reversed(shape)
+	}
+	na := New[T](dims...)
+	if err := decodeValues(na.Data, data, typ, order); err != nil {
+		return nil, fmt.Errorf("npy: can't read data: %v", err)
+	}
+	if fortran && na.Rank > 1 {
+		axes := make([]int, na.Rank, na.Rank)
+		for i := range axes {
+			axes[i] = na.Rank - 1 - i
+		}
+		na = na.Permute(axes...).Copy()
+	}
+	return na, nil
+}
+
+// ReadNPYFile reads an narray from a .npy file. See ReadNPY.
+func ReadNPYFile[T Elem](fn string) (*NArray[T], error) {
+
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadNPY[T](f)
+}
+
+// WriteNPY writes the narray in the NumPy .npy format, little-endian and in
+// C order. The header is version 1.0 unless it doesn't fit, then it is 2.0.
+// The array can be loaded in Python with
+//
+//	x = numpy.load("x.npy")
+func (na *NArray[T]) WriteNPY(w io.Writer) error {
+
+	dims := make([]string, len(na.Shape), len(na.Shape))
+	for i, d := range na.Shape {
+		dims[i] = strconv.Itoa(d)
+	}
+	shape := strings.Join(dims, ", ")
+	if len(dims) == 1 {
+		shape += ","
+	}
+	dict := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%s), }",
+		npyDescr[kindOf[T]()], shape)
+
+	// The data starts at a multiple of 64 bytes and the header ends with a newline.
+	var pre bytes.Buffer
+	pre.WriteString(npyMagic)
+	if n := len(npyMagic) + 4 + len(dict) + 1; n <= math.MaxUint16 {
+		pad := (64 - n%64) % 64
+		pre.Write([]byte{1, 0})
+		binary.Write(&pre, binary.LittleEndian, uint16(len(dict)+pad+1))
+		dict += strings.Repeat(" ", pad)
+	} else {
+		n += 2
+		pad := (64 - n%64) % 64
+		pre.Write([]byte{2, 0})
+		binary.Write(&pre, binary.LittleEndian, uint32(len(dict)+pad+1))
+		dict += strings.Repeat(" ", pad)
+	}
+	pre.WriteString(dict + "\n")
+	if _, err := w.Write(pre.Bytes()); err != nil {
+		return err
+	}
+	return writeLittle(w, na.Contiguous().data())
+}
+
+// WriteNPYFile writes the narray to a .npy file. See WriteNPY.
+func (na *NArray[T]) WriteNPYFile(fn string) error {
+
+	e := os.MkdirAll(filepath.Dir(fn), 0755)
+	if e != nil {
+		return e
+	}
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+	if err := na.WriteNPY(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadNPZ reads the named narrays of a NumPy .npz archive, as written by
+// numpy.savez or numpy.savez_compressed. The names don't have the .npy
+// extension. The values are converted to the element type of the result,
+// see ReadNPY.
+func ReadNPZ[T Elem](r io.ReaderAt, size int64) (map[string]*NArray[T], error) {
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("npz: %v", err)
+	}
+	arrays := make(map[string]*NArray[T], len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("npz: %s: %v", f.Name, err)
+		}
+		na, err := ReadNPY[T](rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("npz: %s: %v", f.Name, err)
+		}
+		arrays[strings.TrimSuffix(f.Name, ".npy")] = na
+	}
+	return arrays, nil
+}
+
+// ReadNPZFile reads the named narrays of a .npz file. See ReadNPZ.
+func ReadNPZFile[T Elem](fn string) (map[string]*NArray[T], error) {
+
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return ReadNPZ[T](f, fi.Size())
+}
+
+// WriteNPZ writes named narrays to a NumPy .npz archive, in order of name.
+// The arrays are compressed if compress is true, like numpy.savez_compressed.
+// In Python, the archive is a dict-like object:
+//
+//	z = numpy.load("model.npz")
+//	mean = z["mean"]
+func WriteNPZ[T Elem](w io.Writer, arrays map[string]*NArray[T], compress bool) error {
+
+	names := make([]string, 0, len(arrays))
+	for name := range arrays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	method := zip.Store
+	if compress {
+		method = zip.Deflate
+	}
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: method})
+		if err != nil {
+			return fmt.Errorf("npz: %s: %v", name, err)
+		}
+		if err := arrays[name].WriteNPY(f); err != nil {
+			return fmt.Errorf("npz: %s: %v", name, err)
+		}
+	}
+	return zw.Close()
+}
+
+// WriteNPZFile writes named narrays to a .npz file. See WriteNPZ.
+func WriteNPZFile[T Elem](fn string, arrays map[string]*NArray[T], compress bool) error {
+
+	e := os.MkdirAll(filepath.Dir(fn), 0755)
+	if e != nil {
+		return e
+	}
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+	if err := WriteNPZ(f, arrays, compress); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// parseNPYHeader parses the dict of a .npy header.
+func parseNPYHeader(h string) (typ reflect.Type, order binary.ByteOrder, fortran bool, shape []int, err error) {
+
+	m := npyDescrRE.FindStringSubmatch(h)
+	if m == nil {
+		return nil, nil, false, nil, fmt.Errorf("npy: unsupported dtype in header %q", strings.TrimSpace(h))
+	}
+	descr := m[1]
+	order = binary.LittleEndian
+	switch {
+	case strings.HasPrefix(descr, ">"):
+		order = binary.BigEndian
+		descr = descr[1:]
+	case strings.HasPrefix(descr, "<"), strings.HasPrefix(descr, "|"), strings.HasPrefix(descr, "="):
+		descr = descr[1:]
+	}
+	typ, ok := npyTypes[descr]
+	if !ok {
+		return nil, nil, false, nil, fmt.Errorf("npy: unsupported dtype %q", m[1])
+	}
+
+	m = npyFortranRE.FindStringSubmatch(h)
+	if m == nil {
+		return nil, nil, false, nil, fmt.Errorf("npy: missing fortran_order in header %q", strings.TrimSpace(h))
+	}
+	fortran = m[1] == "True"
+
+	m = npyShapeRE.FindStringSubmatch(h)
+	if m == nil {
+		return nil, nil, false, nil, fmt.Errorf("npy: missing shape in header %q", strings.TrimSpace(h))
+	}
+	shape = []int{}
+	for _, s := range strings.Split(m[1], ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		d, err := strconv.Atoi(strings.TrimSuffix(s, "L"))
+		if err != nil || d < 0 {
+			return nil, nil, false, nil, fmt.Errorf("npy: invalid shape (%s)", m[1])
+		}
+		shape = append(shape, d)
+	}
+	return typ, order, fortran, shape, nil
+}
+
+// decodeValues sets the values of dst to the values of type typ encoded
+// in data with byte order order. See convertValues.
+func decodeValues[T Elem](dst []T, data []byte, typ reflect.Type, order binary.ByteOrder) error {
+
+	if k, ok := kinds[typ.Kind()]; ok && k == kindOf[T]() {
+		if littleEndian && order == binary.LittleEndian {
+			copy(bytesOf(dst), data)
+			return nil
+		}
+		return binary.Read(bytes.NewReader(data), order, dst)
+	}
+	src := reflect.MakeSlice(reflect.SliceOf(typ), len(dst), len(dst)).Interface()
+	if err := binary.Read(bytes.NewReader(data), order, src); err != nil {
+		return err
+	}
+	return convertValues(dst, src)
+}
+
+// convertValues sets the values of dst to the values of src, a slice of
+// another element type or of bools. Floats are rounded and clamped when
+// converted to integers, integers are converted as in Go. Returns an error
+// if complex values would be discarded.
+func convertValues[T Elem](dst []T, src any) error {
+
+	switch s := src.(type) {
+	case []bool:
+		for i, v := range s {
+			if v {
+				dst[i] = fromInt64[T](1)
+			} else {
+				dst[i] = fromInt64[T](0)
+			}
+		}
+	case []int8:
+		for i, v := range s {
+			dst[i] = fromInt64[T](int64(v))
+		}
+	case []int16:
+		for i, v := range s {
+			dst[i] = fromInt64[T](int64(v))
+		}
+	case []int32:
+		for i, v := range s {
+			dst[i] = fromInt64[T](int64(v))
+		}
+	case []int64:
+		for i, v := range s {
+			dst[i] = fromInt64[T](v)
+		}
+	case []uint8:
+		for i, v := range s {
+			dst[i] = fromUint64[T](uint64(v))
+		}
+	case []uint16:
+		for i, v := range s {
+			dst[i] = fromUint64[T](uint64(v))
+		}
+	case []uint32:
+		for i, v := range s {
+			dst[i] = fromUint64[T](uint64(v))
+		}
+	case []uint64:
+		for i, v := range s {
+			dst[i] = fromUint64[T](v)
+		}
+	case []float32:
+		for i, v := range s {
+			dst[i] = fromFloat64[T](float64(v))
+		}
+	case []float64:
+		for i, v := range s {
+			dst[i] = fromFloat64[T](v)
+		}
+	case []complex64:
+		if !kindOf[T]().isComplex() {
+			var v T
+			return fmt.Errorf("can't convert complex64 values to %T", v)
+		}
+		for i, v := range s {
+			dst[i] = fromComplex128[T](complex128(v))
+		}
+	case []complex128:
+		if !kindOf[T]().isComplex() {
+			var v T
+			return fmt.Errorf("can't convert complex128 values to %T", v)
+		}
+		for i, v := range s {
+			dst[i] = fromComplex128[T](v)
+		}
+	default:
+		return fmt.Errorf("can't convert %T values", src)
+	}
+	return nil
+}
+
+// fromInt64 returns v as an element of type T, converted as in Go.
+func fromInt64[T Elem](v int64) T {
+
+	var res T
+	p := unsafe.Pointer(&res)
+	switch kindOf[T]() {
+	case float64Kind:
+		*(*float64)(p) = float64(v)
+	case float32Kind:
+		*(*float32)(p) = float32(v)
+	case complex128Kind:
+		*(*complex128)(p) = complex(float64(v), 0)
+	case complex64Kind:
+		*(*complex64)(p) = complex(float32(v), 0)
+	case int8Kind:
+		*(*int8)(p) = int8(v)
+	case int16Kind:
+		*(*int16)(p) = int16(v)
+	case int32Kind:
+		*(*int32)(p) = int32(v)
+	case int64Kind:
+		*(*int64)(p) = v
+	case uint8Kind:
+		*(*uint8)(p) = uint8(v)
+	case uint16Kind:
+		*(*uint16)(p) = uint16(v)
+	case uint32Kind:
+		*(*uint32)(p) = uint32(v)
+	default:
+		*(*uint64)(p) = uint64(v)
+	}
+	return res
+}
+
+// fromUint64 returns v as an element of type T, converted as in Go.
+func fromUint64[T Elem](v uint64) T {
+
+	var res T
+	p := unsafe.Pointer(&res)
+	switch kindOf[T]() {
+	case float64Kind:
+		*(*float64)(p) = float64(v)
+	case float32Kind:
+		*(*float32)(p) = float32(v)
+	case complex128Kind:
+		*(*complex128)(p) = complex(float64(v), 0)
+	case complex64Kind:
+		*(*complex64)(p) = complex(float32(v), 0)
+	default:
+		// Integers of any size and sign wrap around as in Go.
+		res = fromInt64[T](int64(v))
+	}
+	return res
+}
+
+// fromComplex128 returns v as an element of type T. Real types get the
+// real part, see fromFloat64.
+func fromComplex128[T Elem](v complex128) T {
+
+	var res T
+	p := unsafe.Pointer(&res)
+	switch kindOf[T]() {
+	case complex128Kind:
+		*(*complex128)(p) = v
+	case complex64Kind:
+		*(*complex64)(p) = complex64(v)
+	default:
+		res = fromFloat64[T](real(v))
+	}
+	return res
+}
+
+// reversed returns a copy of a in reverse order.
+func reversed(a []int) []int {
+
+	r := make([]int, len(a), len(a))
+	for i, v := range a {
+		r[len(a)-1-i] = v
+	}
+	return r
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import (
+	"fmt"
+)
+
+// SumAxis returns the sum of the elements along the specified axes.
+//
+// Example, given an narray x with shape 5x3, the sum of each row is:
+//
+//	y := SumAxis(nil, x, false, 1) // y has shape 5
+//	y := SumAxis(nil, x, true, 1)  // y has shape 5x1
+//
+// If no axes are given, the sum is computed over all axes. When keepDims is true,
+// the reduced axes are kept with size one so that the result can be broadcast
+// against the input.
+// If out is nil a new array is created.
+// Will panic if an axis is out of range or if the shape of out doesn't match.
+func SumAxis[T Real](out, in *NArray[T], keepDims bool, axes ...int) *NArray[T] {
+	return reduce("SumAxis", out, in, keepDims, axes, sliceSum, addSlice, 0)
+}
+
+// MeanAxis returns the mean of the elements along the specified axes.
+// See SumAxis for details.
+func MeanAxis[T Float](out, in *NArray[T], keepDims bool, axes ...int) *NArray[T] {
+
+	out = SumAxis(out, in, keepDims, axes...)
+	if out.Size() > 0 && in.Size() > 0 {
+		Scale(out, out, T(out.Size())/T(in.Size()))
+	}
+	return out
+}
+
+// ProdAxis returns the product of the elements along the specified axes.
+// See SumAxis for details.
+func ProdAxis[T Real](out, in *NArray[T], keepDims bool, axes ...int) *NArray[T] {
+	return reduce("ProdAxis", out, in, keepDims, axes, func(x []T) T {
+		p := T(1.0)
+		for _, v := range x {
+			p *= v
+		}
+		return p
+	}, mulSlice, 1)
+}
+
+// MaxAxis returns the max value along the specified axes.
+// See SumAxis for details.
+func MaxAxis[T Float](out, in *NArray[T], keepDims bool, axes ...int) *NArray[T] {
+	return reduce("MaxAxis", out, in, keepDims, axes, maxSliceElement, maxSlice, -maxValue[T]())
+}
+
+// MinAxis returns the min value along the specified axes.
+// See SumAxis for details.
+func MinAxis[T Float](out, in *NArray[T], keepDims bool, axes ...int) *NArray[T] {
+	return reduce("MinAxis", out, in, keepDims, axes, minSliceElement, minSlice, maxValue[T]())
+}
+
+// ArgMaxAxis returns the indices of the max values along an axis.
+// The indices are returned in row-major order of the remaining axes.
+//
+// Example, given an narray x with shape frames x states, find
+// the best state for each frame:
+//
+//	best := ArgMaxAxis(x, 1) // len(best) == frames
+//
+// Will panic if the axis is out of range or has size zero.
+func ArgMaxAxis[T Real](in *NArray[T], axis int) []int {
+	return argAxis("ArgMaxAxis", in, axis, func(a, b T) bool { return a > b })
+}
+
+// ArgMinAxis returns the indices of the min values along an axis.
+// See ArgMaxAxis for details.
+func ArgMinAxis[T Real](in *NArray[T], axis int) []int {
+	return argAxis("ArgMinAxis", in, axis, func(a, b T) bool { return a < b })
+}
+
+// splitAxes returns two views of in: outer with the axes that are kept
+// and inner with the reduced axes, and the shape of the result.
+// Will panic with a *ShapeError for operation op if an axis is invalid.
+func splitAxes[T Real](op string, in *NArray[T], keepDims bool, axes []int) (outer, inner *NArray[T], shape []int) {
+
+	reduced := make([]bool, in.Rank, in.Rank)
+	for _, a := range axes {
+		if a < 0 || a >= in.Rank {
+			panic(shapeError(op, fmt.Sprintf("axis [%d] out of range", a), in))
+		}
+		if reduced[a] {
+			panic(shapeError(op, fmt.Sprintf("duplicate axis [%d]", a), in))
+		}
+		reduced[a] = true
+	}
+	if len(axes) == 0 {
+		for k := range reduced {
+			reduced[k] = true
+		}
+	}
+
+	outer = &NArray[T]{Data: in.Data, Offset: in.Offset}
+	inner = &NArray[T]{Data: in.Data, Offset: in.Offset}
+	shape = []int{}
+	for k, v := range in.Shape {
+		if reduced[k] {
+			inner.Shape = append(inner.Shape, v)
+			inner.Strides = append(inner.Strides, in.Strides[k])
+			if keepDims {
+				shape = append(shape, 1)
+			}
+			continue
+		}
+		outer.Shape = append(outer.Shape, v)
+		outer.Strides = append(outer.Strides, in.Strides[k])
+		shape = append(shape, v)
+	}
+	outer.Rank = len(outer.Shape)
+	inner.Rank = len(inner.Shape)
+	return
+}
+
+// reduce applies a reduction along axes.
+//
+// When the reduced elements are contiguous, fn reduces each block in place.
+// When the remaining elements are contiguous instead, acc combines the
+// blocks elementwise. Otherwise the reduced elements are gathered into a buffer.
+// The value empty is used when the reduced axes have size zero.
+func reduce[T Real](op string, out, in *NArray[T], keepDims bool, axes []int,
+	fn func(a []T) T, acc func(out, a, b []T), empty T) *NArray[T] {
+
+	outer, inner, shape := splitAxes(op, in, keepDims, axes)
+	if out == nil {
+		out = New[T](shape...)
+	} else if !EqualShape(out, &NArray[T]{Shape: shape}) {
+		panic(&ShapeError{Op: op, Msg: "out shape doesn't match reduced shape", Shapes: [][]int{out.Shape, shape}})
+	}
+
+	m := outer.Size()
+	n := inner.Size()
+	res := make([]T, m, m)
+	switch {
+	case m == 0:
+	case n == 0:
+		for k := range res {
+			res[k] = empty
+		}
+	case inner.IsContiguous():
+		outer.walk(func(k, idx int) {
+			res[k] = fn(in.Data[idx : idx+n])
+		})
+	case outer.IsContiguous():
+		inner.walk(func(k, idx int) {
+			if k == 0 {
+				copy(res, in.Data[idx:idx+m])
+				return
+			}
+			acc(res, res, in.Data[idx:idx+m])
+		})
+	default:
+		buf := make([]T, n, n)
+		outer.walk(func(k, idx int) {
+			inner.Offset = idx
+			inner.gather(buf)
+			res[k] = fn(buf)
+		})
+	}
+	out.scatter(res)
+	return out
+}
+
+// argAxis returns the index of the element along axis for which better
+// returns true when compared to all the other elements.
+func argAxis[T Real](op string, in *NArray[T], axis int, better func(a, b T) bool) []int {
+
+	outer, _, _ := splitAxes(op, in, false, []int{axis})
+	n := in.Shape[axis]
+	if n == 0 {
+		panic(shapeError(op, fmt.Sprintf("axis [%d] has size zero", axis), in))
+	}
+	stride := in.Strides[axis]
+	res := make([]int, outer.Size(), outer.Size())
+	outer.walk(func(k, idx int) {
+		best := in.Data[idx]
+		for j := 1; j < n; j++ {
+			if v := in.Data[idx+j*stride]; better(v, best) {
+				best = v
+				res[k] = j
+			}
+		}
+	})
+	return res
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package {{.Package}}
+
+import "testing"
+
+func TestSumAxis(t *testing.T) {
+
+	// Reduce over the last axis (contiguous).
+	s := SumAxis(nil, na234, false, 2)
+	if s.Rank != 2 || s.Shape[0] != 2 || s.Shape[1] != 3 {
+		t.Fatalf("expected shape [2 3], got %v", s.Shape)
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			if s.At(i, j) != na234.SubArray(i, j, -1).Sum() {
+				t.Fatalf("expected %f, got %f", na234.SubArray(i, j, -1).Sum(), s.At(i, j))
+			}
+		}
+	}
+
+	// Reduce over the first axis (rows are accumulated).
+	s = SumAxis(nil, na234, true, 0)
+	if s.Rank != 3 || s.Shape[0] != 1 || s.Shape[1] != 3 || s.Shape[2] != 4 {
+		t.Fatalf("expected shape [1 3 4], got %v", s.Shape)
+	}
+	if s.At(0, 1, 2) != na234.At(0, 1, 2)+na234.At(1, 1, 2) {
+		t.Fatalf("expected %f, got %f", na234.At(0, 1, 2)+na234.At(1, 1, 2), s.At(0, 1, 2))
+	}
+
+	// Reduce over the middle axis (gather).
+	s = SumAxis(nil, na234, false, 1)
+	if s.At(1, 3) != na234.SubArray(1, -1, 3).Sum() {
+		t.Fatalf("expected %f, got %f", na234.SubArray(1, -1, 3).Sum(), s.At(1, 3))
+	}
+
+	// Reduce over several axes and use out.
+	out := New(3)
+	SumAxis(out, na234, false, 0, 2)
+	if out.At(2) != na234.SubArray(-1, 2, -1).Copy().Sum() {
+		t.Fatalf("expected %f, got %f", na234.SubArray(-1, 2, -1).Copy().Sum(), out.At(2))
+	}
+
+	// Reduce over all axes.
+	s = SumAxis(nil, na234, false)
+	if s.Rank != 0 || s.At() != na234.Sum() {
+		t.Fatalf("expected %f, got %f", na234.Sum(), s.At())
+	}
+
+	if !panics(func() { SumAxis(nil, na234, false, 3) }) {
+		t.Errorf("did not panic with axis out of range")
+	}
+	if !panics(func() { SumAxis(New(2), na234, false, 1) }) {
+		t.Errorf("did not panic with wrong out shape")
+	}
+}
+
+func TestMeanAxis(t *testing.T) {
+
+	m := MeanAxis(nil, x, true, 0)
+	z := Sub(nil, x, m)
+	for _, v := range SumAxis(nil, z, false, 0).Data {
+		if v != 0 {
+			t.Fatalf("expected zero mean, got %f", v)
+		}
+	}
+}
+
+func TestProdMaxMinAxis(t *testing.T) {
+
+	for axis := 0; axis < 3; axis++ {
+		p := ProdAxis(nil, randna[0], false, axis)
+		max := MaxAxis(nil, randna[0], false, axis)
+		min := MinAxis(nil, randna[0], false, axis)
+		query := []int{-1, -1, -1, -1}
+		for k := 0; k < p.Size(); k++ {
+			idx := p.ReverseIndex(k)
+			for i, j := 0, 0; i < 4; i++ {
+				if i == axis {
+					query[i] = -1
+					continue
+				}
+				query[i] = idx[j]
+				j++
+			}
+			sa := randna[0].SubArray(query...)
+			if !EqualValues(NewArray([]{{.Format}}{p.Data[k]}), NewArray([]{{.Format}}{sa.Prod()}), 0.0001) {
+				t.Fatalf("axis %d: expected prod %f, got %f", axis, sa.Prod(), p.Data[k])
+			}
+			if max.Data[k] != sa.Max() {
+				t.Fatalf("axis %d: expected max %f, got %f", axis, sa.Max(), max.Data[k])
+			}
+			if min.Data[k] != sa.Min() {
+				t.Fatalf("axis %d: expected min %f, got %f", axis, sa.Min(), min.Data[k])
+			}
+		}
+	}
+}
+
+func TestArgMaxAxis(t *testing.T) {
+
+	na := x.Copy()
+	na.Set(100, 1, 3)
+	best := ArgMaxAxis(na, 1)
+	if len(best) != 3 || best[0] != 4 || best[1] != 3 || best[2] != 4 {
+		t.Fatalf("expected [4 3 4], got %v", best)
+	}
+	worst := ArgMinAxis(na, 0)
+	for j, v := range worst {
+		if v != 0 {
+			t.Fatalf("expected 0 for column %d, got %d", j, v)
+		}
+	}
+}
+
+func BenchmarkSumAxis0(b *testing.B) {
+	na := New(1000, 40)
+	for i := 0; i < b.N; i++ {
+		SumAxis(nil, na, false, 0)
+	}
+}
+
+func BenchmarkSumAxis1(b *testing.B) {
+	na := New(1000, 40)
+	for i := 0; i < b.N; i++ {
+		SumAxis(nil, na, false, 1)
+	}
+}
@@ -0,0 +1,396 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// A safetensors file is the length of a JSON header as a little-endian
+// uint64, the header and the data of the tensors. The header maps the
+// names of the tensors to their dtype, shape and position in the data:
+//
+//	{"w": {"dtype": "F32", "shape": [2, 3], "data_offsets": [0, 24]},
+//	 "__metadata__": {"format": "pt"}}
+const (
+	safetensorsMetadata = "__metadata__"
+	// safetensorsMaxHeader is the largest header accepted, as in the
+	// reference implementation.
+	safetensorsMaxHeader = 100 << 20
+)
+
+// safetensorsTypes maps the dtypes read without loss of range to Go types.
+// F16 and BF16 values are converted to float32 first.
+var safetensorsTypes = map[string]reflect.Type{
+	"BOOL": reflect.TypeOf(false),
+	"I8":   reflect.TypeOf(int8(0)),
+	"I16":  reflect.TypeOf(int16(0)),
+	"I32":  reflect.TypeOf(int32(0)),
+	"I64":  reflect.TypeOf(int64(0)),
+	"U8":   reflect.TypeOf(uint8(0)),
+	"U16":  reflect.TypeOf(uint16(0)),
+	"U32":  reflect.TypeOf(uint32(0)),
+	"U64":  reflect.TypeOf(uint64(0)),
+	"F16":  reflect.TypeOf(uint16(0)),
+	"BF16": reflect.TypeOf(uint16(0)),
+	"F32":  reflect.TypeOf(float32(0)),
+	"F64":  reflect.TypeOf(float64(0)),
+	"C64":  reflect.TypeOf(complex64(0)),
+}
+
+// safetensorsDType maps element kinds to the dtype written by SaveSafetensors.
+// The format has no dtype for complex128 values.
+var safetensorsDType = map[kind]string{
+	float32Kind:   "F32",
+	float64Kind:   "F64",
+	complex64Kind: "C64",
+	int8Kind:      "I8",
+	int16Kind:     "I16",
+	int32Kind:     "I32",
+	int64Kind:     "I64",
+	uint8Kind:     "U8",
+	uint16Kind:    "U16",
+	uint32Kind:    "U32",
+	uint64Kind:    "U64",
+}
+
+// SafetensorsInfo describes a tensor of a safetensors file.
+type SafetensorsInfo struct {
+	// DType is the type of the values, such as "F32" or "BF16".
+	DType string `json:"dtype"`
+	// Shape is the shape of the tensor.
+	Shape []int `json:"shape"`
+	// Offsets are the positions of the first value and after the last
+	// value, relative to the end of the header.
+	Offsets [2]int64 `json:"data_offsets"`
+}
+
+// Safetensors is a safetensors file whose tensors are read when needed, so
+// that a few tensors can be read from a large file:
+//
+//	st, err := na32.LoadSafetensors("model.safetensors")
+//	if err != nil {
+//	    return err
+//	}
+//	defer st.Close()
+//	w, err := st.Get("encoder.layer.0.weight")
+//
+// The values are converted to the element type of the narrays, like ReadNPY
+// does. F16 and BF16 values are supported, F8 values are not.
+type Safetensors[T Elem] struct {
+	// Metadata holds the free-form string map of the file, it may be nil.
+	Metadata map[string]string
+	r        io.ReaderAt
+	closer   io.Closer
+	// base is the position of the data and size the size of the file.
+	base    int64
+	size    int64
+	tensors map[string]SafetensorsInfo
+}
+
+// ReadSafetensors reads the header of a safetensors file of size bytes
+// from r. The tensors are read from r by Get. Returns an error if the
+// data of a tensor is not within the file, or if the data of the tensors
+// has gaps or overlaps.
+func ReadSafetensors[T Elem](r io.ReaderAt, size int64) (*Safetensors[T], error) {
+
+	var n [8]byte
+	if _, err := r.ReadAt(n[:], 0); err != nil {
+		return nil, fmt.Errorf("safetensors: can't read header length: %v", err)
+	}
+	hlen := binary.LittleEndian.Uint64(n[:])
+	if hlen > safetensorsMaxHeader || int64(hlen) > size-8 {
+		return nil, fmt.Errorf("safetensors: header length [%d] is too large for a file of [%d] bytes", hlen, size)
+	}
+	header := make([]byte, hlen, hlen)
+	if _, err := r.ReadAt(header, 8); err != nil {
+		return nil, fmt.Errorf("safetensors: can't read header: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(header, &fields); err != nil {
+		return nil, fmt.Errorf("safetensors: invalid header: %v", err)
+	}
+
+	st := &Safetensors[T]{
+		r:       r,
+		base:    8 + int64(hlen),
+		size:    size,
+		tensors: make(map[string]SafetensorsInfo, len(fields)),
+	}
+	for name, raw := range fields {
+		if name == safetensorsMetadata {
+			if err := json.Unmarshal(raw, &st.Metadata); err != nil {
+				return nil, fmt.Errorf("safetensors: invalid metadata: %v", err)
+			}
+			continue
+		}
+		var info SafetensorsInfo
+		if err := json.Unmarshal(raw, &info); err != nil {
+			return nil, fmt.Errorf("safetensors: invalid header of tensor %q: %v", name, err)
+		}
+		if err := info.check(st.size - st.base); err != nil {
+			return nil, fmt.Errorf("safetensors: tensor %q: %v", name, err)
+		}
+		st.tensors[name] = info
+	}
+
+	// The data of the tensors must fill the rest of the file, without gaps
+	// or overlaps.
+	infos := make([]SafetensorsInfo, 0, len(st.tensors))
+	for _, info := range st.tensors {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		a, b := infos[i].Offsets, infos[j].Offsets
+		return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
+	})
+	var end int64
+	for _, info := range infos {
+		if info.Offsets[0] != end {
+			return nil, fmt.Errorf("safetensors: data_offsets %v don't start at the end of the previous tensor, [%d]", info.Offsets, end)
+		}
+		end = info.Offsets[1]
+	}
+	if end != st.size-st.base {
+		return nil, fmt.Errorf("safetensors: the tensors have [%d] bytes of data, the file has [%d]", end, st.size-st.base)
+	}
+	return st, nil
+}
+
+// LoadSafetensors opens a safetensors file and reads its header. The file
+// stays open until Close is called. See ReadSafetensors.
+func LoadSafetensors[T Elem](fn string) (*Safetensors[T], error) {
+
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	st, err := ReadSafetensors[T](f, fi.Size())
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	st.closer = f
+	return st, nil
+}
+
+// Names returns the names of the tensors in order.
+func (st *Safetensors[T]) Names() []string {
+
+	names := make([]string, 0, len(st.tensors))
+	for name := range st.tensors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Info returns the description of a tensor and false if there is no
+// tensor with that name.
+func (st *Safetensors[T]) Info(name string) (SafetensorsInfo, bool) {
+	info, ok := st.tensors[name]
+	return info, ok
+}
+
+// Get reads a tensor. Returns an error if there is no tensor with that name
+// or if its dtype is not supported.
+func (st *Safetensors[T]) Get(name string) (*NArray[T], error) {
+
+	info, ok := st.tensors[name]
+	if !ok {
+		return nil, fmt.Errorf("safetensors: no tensor %q", name)
+	}
+	typ, ok := safetensorsTypes[info.DType]
+	if !ok {
+		return nil, fmt.Errorf("safetensors: tensor %q has unsupported dtype %q", name, info.DType)
+	}
+	if info.DType == "C64" && !kindOf[T]().isComplex() {
+		return nil, fmt.Errorf("safetensors: can't read complex tensor %q into a real narray", name)
+	}
+
+	na := New[T](append([]int{}, info.Shape...)...)
+	sr := io.NewSectionReader(st.r, st.base+info.Offsets[0], info.Offsets[1]-info.Offsets[0])
+	var err error
+	if k, ok := kinds[typ.Kind()]; ok && k == kindOf[T]() && info.DType != "F16" && info.DType != "BF16" {
+		err = readLittle(sr, na.Data)
+	} else {
+		src := reflect.MakeSlice(reflect.SliceOf(typ), len(na.Data), len(na.Data))
+		if err = binary.Read(sr, binary.LittleEndian, src.Interface()); err == nil {
+			if info.DType == "F16" || info.DType == "BF16" {
+				src = reflect.ValueOf(halfToFloat(src.Interface().([]uint16), info.DType == "BF16"))
+			}
+			err = convertValues(na.Data, src.Interface())
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("safetensors: can't read tensor %q: %v", name, err)
+	}
+	return na, nil
+}
+
+// GetAll reads all the tensors in order of name. Returns the error of
+// the first tensor that can't be read.
+func (st *Safetensors[T]) GetAll() (map[string]*NArray[T], error) {
+
+	arrays := make(map[string]*NArray[T], len(st.tensors))
+	for _, name := range st.Names() {
+		na, err := st.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		arrays[name] = na
+	}
+	return arrays, nil
+}
+
+// Close closes the file opened by LoadSafetensors.
+func (st *Safetensors[T]) Close() error {
+
+	if st.closer == nil {
+		return nil
+	}
+	err := st.closer.Close()
+	st.closer = nil
+	return err
+}
+
+// WriteSafetensors writes named narrays and optional metadata in the
+// safetensors format. The tensors are stored in order of name.
+// Returns an error for complex128 narrays, which have no safetensors dtype.
+func WriteSafetensors[T Elem](w io.Writer, arrays map[string]*NArray[T], metadata map[string]string) error {
+
+	dtype, ok := safetensorsDType[kindOf[T]()]
+	if !ok {
+		var v T
+		return fmt.Errorf("safetensors: %T values are not supported", v)
+	}
+	names := make([]string, 0, len(arrays))
+	for name := range arrays {
+		if name == safetensorsMetadata {
+			return fmt.Errorf("safetensors: %q is not a valid tensor name", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var v T
+	size := int64(reflect.TypeOf(v).Size())
+	fields := make(map[string]interface{}, len(arrays)+1)
+	if len(metadata) > 0 {
+		fields[safetensorsMetadata] = metadata
+	}
+	var off int64
+	for _, name := range names {
+		na := arrays[name]
+		n := int64(na.Size()) * size
+		fields[name] = SafetensorsInfo{DType: dtype, Shape: append([]int{}, na.Shape...), Offsets: [2]int64{off, off + n}}
+		off += n
+	}
+	header, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("safetensors: %v", err)
+	}
+	// The data starts at a multiple of eight bytes.
+	header = append(header, strings.Repeat(" ", (8-len(header)%8)%8)...)
+
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, uint64(len(header)))
+	b.Write(header)
+	if _, err := w.Write(b.Bytes()); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := writeLittle(w, arrays[name].Contiguous().data()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveSafetensors writes named narrays and optional metadata to a
+// safetensors file. See WriteSafetensors.
+func SaveSafetensors[T Elem](fn string, arrays map[string]*NArray[T], metadata map[string]string) error {
+
+	e := os.MkdirAll(filepath.Dir(fn), 0755)
+	if e != nil {
+		return e
+	}
+	f, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+	if err := WriteSafetensors(f, arrays, metadata); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// check returns an error if the offsets are not within the size bytes of
+// data or if they don't match the shape. The size of
+// unsupported dtypes is not known, they are reported by Get.
+func (info SafetensorsInfo) check(size int64) error {
+
+	n := int64(1)
+	for _, d := range info.Shape {
+		if d < 0 || (d > 0 && n > math.MaxInt64/int64(d)) {
+			return fmt.Errorf("invalid shape %v", info.Shape)
+		}
+		n *= int64(d)
+	}
+	if info.Offsets[0] < 0 || info.Offsets[1] < info.Offsets[0] || info.Offsets[1] > size {
+		return fmt.Errorf("data_offsets %v are not within the [%d] bytes of data", info.Offsets, size)
+	}
+	typ, ok := safetensorsTypes[info.DType]
+	if ok && (n > size || info.Offsets[1]-info.Offsets[0] != n*int64(typ.Size())) {
+		return fmt.Errorf("data_offsets %v don't match shape %v of %s values", info.Offsets, info.Shape, info.DType)
+	}
+	return nil
+}
+
+// halfToFloat converts IEEE half precision values, or bfloat16 values if
+// brain is true, to float32.
+func halfToFloat(h []uint16, brain bool) []float32 {
+
+	f := make([]float32, len(h), len(h))
+	for i, v := range h {
+		if brain {
+			f[i] = math.Float32frombits(uint32(v) << 16)
+			continue
+		}
+		sign := uint32(v>>15) << 31
+		exp := uint32(v>>10) & 0x1f
+		mant := uint32(v & 0x3ff)
+		switch exp {
+		case 0:
+			// Zero and subnormal values are mant * 2^-24.
+			f[i] = float32(math.Ldexp(float64(mant), -24))
+			if sign != 0 {
+				f[i] = -f[i]
+			}
+		case 0x1f:
+			f[i] = math.Float32frombits(sign | 0xff<<23 | mant<<13)
+		default:
+			f[i] = math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+		}
+	}
+	return f
+}
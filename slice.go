@@ -0,0 +1,194 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package narray
+
+import (
+	"fmt"
+	"math"
+)
+
+// End can be used as Range.Stop to select elements up to the end of the
+// axis in the direction of Range.Step.
+const End = math.MaxInt32
+
+// SliceSpec selects elements along the axes of an narray.
+// The possible values are Range, Pick, NewAxis and Ellipsis. See Slice.
+type SliceSpec interface {
+	sliceSpec()
+}
+
+// Range selects the elements Start, Start+Step, Start+2*Step, ... along an axis,
+// stopping before Stop. Negative values of Start and Stop are counted from the
+// end of the axis, -1 being the last element. Out of range values are clipped.
+// A zero Step is interpreted as 1.
+//
+//	Range{100, 200, 1} // elements 100 to 199
+//	Range{0, End, 2}   // every second element
+//	Range{-1, End, -1} // all elements in reverse order
+type Range struct {
+	Start, Stop, Step int
+}
+
+// All selects all the elements along an axis.
+var All = Range{0, End, 1}
+
+// Pick selects a single element along an axis and removes the axis
+// from the result. Negative values are counted from the end of the axis.
+type Pick int
+
+// Marker is the type of the NewAxis and Ellipsis slice specs.
+type Marker int
+
+const (
+	// NewAxis inserts an axis of size one.
+	NewAxis Marker = iota
+	// Ellipsis selects all elements along as many axes as needed
+	// to match the rank of the narray.
+	Ellipsis
+)
+
+func (Range) sliceSpec()  {}
+func (Pick) sliceSpec()   {}
+func (Marker) sliceSpec() {}
+
+// Slice returns a view of the narray selected by one spec per axis.
+// Missing trailing specs select all the elements of the remaining axes.
+// The view shares data with the narray, use Copy to get a contiguous narray.
+//
+// Example, given an narray x with shape frames x coeffs, select frames
+// 100 to 199 and every second coefficient:
+//
+//	y := x.Slice(Range{100, 200, 1}, Range{0, End, 2})
+//
+// Select coefficient 3 of the last frame:
+//
+//	y := x.Slice(Pick(-1), Pick(3)) // y is a scalar
+//
+// Select the last axis of an narray of any rank and append an axis of size one:
+//
+//	y := x.Slice(Ellipsis, Pick(0), NewAxis)
+//
+// Will panic if there are too many specs, more than one Ellipsis, or if a Pick
+// index is out of range.
+func (na *NArray[T]) Slice(specs ...SliceSpec) *NArray[T] {
+
+	// Count specs that consume an axis and expand the ellipsis.
+	n := 0
+	ellipsis := -1
+	for k, spec := range specs {
+		switch spec {
+		case NewAxis:
+		case Ellipsis:
+			if ellipsis >= 0 {
+				panic(shapeError("Slice", "only one ellipsis is allowed", na))
+			}
+			ellipsis = k
+		default:
+			n++
+		}
+	}
+	if n > na.Rank {
+		panic(shapeError("Slice", fmt.Sprintf("too many specs [%d]", n), na))
+	}
+	expanded := make([]SliceSpec, 0, len(specs)+na.Rank-n)
+	for k, spec := range specs {
+		if k == ellipsis {
+			for i := 0; i < na.Rank-n; i++ {
+				expanded = append(expanded, All)
+			}
+			continue
+		}
+		expanded = append(expanded, spec)
+	}
+	if ellipsis < 0 {
+		for i := n; i < na.Rank; i++ {
+			expanded = append(expanded, All)
+		}
+	}
+
+	var shape, strides []int
+	offset := na.Offset
+	axis := 0
+	for _, spec := range expanded {
+		switch v := spec.(type) {
+		case Marker:
+			shape = append(shape, 1)
+			strides = append(strides, 0)
+			continue
+		case Pick:
+			i := int(v)
+			if i < 0 {
+				i += na.Shape[axis]
+			}
+			if i < 0 || i >= na.Shape[axis] {
+				panic(indexError([]int{int(v)}, na.Shape[axis:axis+1]))
+			}
+			offset += i * na.Strides[axis]
+		case Range:
+			start, size, step := v.indices(na.Shape[axis])
+			if size > 0 {
+				offset += start * na.Strides[axis]
+			}
+			shape = append(shape, size)
+			strides = append(strides, step*na.Strides[axis])
+		}
+		axis++
+	}
+	return &NArray[T]{
+		Rank:    len(shape),
+		Shape:   shape,
+		Data:    na.Data,
+		Strides: strides,
+		Offset:  offset,
+	}
+}
+
+// indices returns the first index, the number of elements and the step
+// selected by the range along an axis of size n.
+func (r Range) indices(n int) (start, size, step int) {
+
+	step = r.Step
+	if step == 0 {
+		step = 1
+	}
+	start, stop := r.Start, r.Stop
+	if start < 0 {
+		start += n
+	}
+	if stop != End && stop < 0 {
+		stop += n
+	}
+	if step > 0 {
+		start = clip(start, 0, n)
+		if stop == End {
+			stop = n
+		}
+		stop = clip(stop, 0, n)
+		if stop > start {
+			size = (stop - start + step - 1) / step
+		}
+		return
+	}
+	start = clip(start, -1, n-1)
+	if stop == End {
+		stop = -1
+	}
+	stop = clip(stop, -1, n-1)
+	if start > stop {
+		size = (start - stop - step - 1) / -step
+	}
+	return
+}
+
+func clip(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2015 AKUALAB INC., All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package {{.Package}}
+
+import "testing"
+
+func TestSlice(t *testing.T) {
+
+	na := New(300, 13)
+	for k := range na.Data {
+		na.Data[k] = {{.Format}}(k)
+	}
+
+	// Window of frames with every second coefficient.
+	w := na.Slice(Range{Start: 100, Stop: 200, Step: 1}, Range{Start: 0, Stop: End, Step: 2})
+	if w.Shape[0] != 100 || w.Shape[1] != 7 {
+		t.Fatalf("expected shape [100 7], got %v", w.Shape)
+	}
+	for i := 0; i < 100; i++ {
+		for j := 0; j < 7; j++ {
+			if w.At(i, j) != na.At(100+i, 2*j) {
+				t.Fatalf("expected %f, got %f", na.At(100+i, 2*j), w.At(i, j))
+			}
+		}
+	}
+
+	// Writes go through to the narray.
+	w.Set(-1, 0, 1)
+	if na.At(100, 2) != -1 {
+		t.Fatalf("expected -1, got %f", na.At(100, 2))
+	}
+
+	// Negative indices and steps.
+	r := na.Slice(Range{Start: -3, Stop: End, Step: 1}, Range{Start: -1, Stop: End, Step: -1})
+	if r.Shape[0] != 3 || r.Shape[1] != 13 {
+		t.Fatalf("expected shape [3 13], got %v", r.Shape)
+	}
+	if r.At(0, 0) != na.At(297, 12) || r.At(2, 12) != na.At(299, 0) {
+		t.Fatalf("reverse slice failed")
+	}
+	r = na.Slice(Pick(5), Range{Start: 10, Stop: 2, Step: -3})
+	if r.Rank != 1 || r.Shape[0] != 3 || r.At(2) != na.At(5, 4) {
+		t.Fatalf("negative step slice failed: %v", r)
+	}
+
+	// Pick a scalar.
+	s := na.Slice(Pick(-1), Pick(3))
+	if s.Rank != 0 || s.At() != na.At(299, 3) {
+		t.Fatalf("expected %f, got %f", na.At(299, 3), s.At())
+	}
+
+	// Empty and clipped ranges.
+	if e := na.Slice(Range{Start: 200, Stop: 100, Step: 1}); e.Shape[0] != 0 {
+		t.Fatalf("expected empty axis, got %v", e.Shape)
+	}
+	if c := na.Slice(Range{Start: 290, Stop: 1000, Step: 1}); c.Shape[0] != 10 {
+		t.Fatalf("expected 10 rows, got %v", c.Shape)
+	}
+
+	if !panics(func() { na.Slice(Pick(300)) }) {
+		t.Errorf("did not panic with index out of range")
+	}
+	if !panics(func() { na.Slice(All, All, All) }) {
+		t.Errorf("did not panic with too many specs")
+	}
+}
+
+func TestSliceMarkers(t *testing.T) {
+
+	e := na234.Slice(Ellipsis, Pick(1))
+	if !EqualValues(e, na234.SubArray(-1, -1, 1), 0) {
+		t.Fatalf("expected same values")
+	}
+	e = na234.Slice(Pick(1), Ellipsis, Range{Start: 0, Stop: 2, Step: 1})
+	if e.Shape[0] != 3 || e.Shape[1] != 2 || e.At(2, 1) != na234.At(1, 2, 1) {
+		t.Fatalf("ellipsis slice failed: %v", e)
+	}
+
+	n := x.Slice(NewAxis, All, NewAxis)
+	if n.Rank != 4 || n.Shape[0] != 1 || n.Shape[1] != 3 || n.Shape[2] != 1 || n.Shape[3] != 5 {
+		t.Fatalf("expected shape [1 3 1 5], got %v", n.Shape)
+	}
+	if n.At(0, 2, 0, 4) != x.At(2, 4) {
+		t.Fatalf("expected %f, got %f", x.At(2, 4), n.At(0, 2, 0, 4))
+	}
+	if !panics(func() { x.Slice(Ellipsis, Ellipsis) }) {
+		t.Errorf("did not panic with two ellipses")
+	}
+}
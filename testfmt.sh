@@ -22,3 +22,43 @@ if [[ -n $(gofmt -d ./na32) ]]; then
 	gofmt -d ./na32
  	exit 1
 fi
+
+if [[ -n $(gofmt -d ./nc64) ]]; then 
+	gofmt -d ./nc64
+ 	exit 1
+fi
+
+if [[ -n $(gofmt -d ./nc128) ]]; then 
+	gofmt -d ./nc128
+ 	exit 1
+fi
+
+if [[ -n $(gofmt -d ./ni32) ]]; then 
+	gofmt -d ./ni32
+ 	exit 1
+fi
+
+if [[ -n $(gofmt -d ./ni64) ]]; then 
+	gofmt -d ./ni64
+ 	exit 1
+fi
+
+if [[ -n $(gofmt -d ./nu8) ]]; then 
+	gofmt -d ./nu8
+ 	exit 1
+fi
+
+if [[ -n $(gofmt -d ./fft) ]]; then 
+	gofmt -d ./fft
+ 	exit 1
+fi
+
+if [[ -n $(gofmt -d ./linalg) ]]; then 
+	gofmt -d ./linalg
+ 	exit 1
+fi
+
+if [[ -n $(gofmt -d ./kaldi) ]]; then 
+	gofmt -d ./kaldi
+ 	exit 1
+fi